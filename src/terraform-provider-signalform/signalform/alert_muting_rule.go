@@ -0,0 +1,214 @@
+package signalform
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const ALERT_MUTING_RULE_API_URL = "https://api.signalfx.com/v2/alertmuting"
+
+func alertMutingRuleResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"synced": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the resource in SignalForm and SignalFx are identical or not. Used internally for syncing.",
+			},
+			"last_updated": &schema.Schema{
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Latest timestamp the resource was updated",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Description of the muting rule",
+			},
+			"start_time": &schema.Schema{
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Seconds since epoch at which the muting rule starts",
+			},
+			"stop_time": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Seconds since epoch at which the muting rule stops, after start_time. If not set, notifications are muted indefinitely",
+			},
+			"recurrence": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Repeat the muting period every value unit (e.g. every 1 week)",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"unit": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateRecurrenceUnit,
+							Description:  "The unit of the period. Must be \"d\" (days) or \"w\" (weeks)",
+						},
+						"value": &schema.Schema{
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The amount of units between two muting periods",
+						},
+					},
+				},
+			},
+			"detectors": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the detectors to mute. If not set, all the detectors matching the filters are muted",
+			},
+			"filter": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Filter on the dimensions of the incidents to mute",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "A metric time series dimension or property name",
+						},
+						"negated": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "(false by default) Whether this filter should be a \"not\" filter",
+						},
+						"values": &schema.Schema{
+							Type:        schema.TypeSet,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "List of strings (which will be treated as an OR filter on the property)",
+						},
+					},
+				},
+			},
+		},
+
+		Create: alertmutingruleCreate,
+		Read:   alertmutingruleRead,
+		Update: alertmutingruleUpdate,
+		Delete: alertmutingruleDelete,
+	}
+}
+
+/*
+  Use Resource object to construct json payload in order to create a muting rule
+*/
+func getPayloadAlertMutingRule(d *schema.ResourceData) ([]byte, error) {
+	payload := map[string]interface{}{
+		"description": d.Get("description").(string),
+		"startTime":   d.Get("start_time").(int) * 1000,
+	}
+
+	if val, ok := d.GetOk("stop_time"); ok {
+		if val.(int) <= d.Get("start_time").(int) {
+			return nil, fmt.Errorf("stop_time (%d) must be after start_time (%d)", val.(int), d.Get("start_time").(int))
+		}
+		payload["stopTime"] = val.(int) * 1000
+	}
+
+	if tf_recurrence, ok := d.GetOk("recurrence"); ok {
+		recurrence := tf_recurrence.(*schema.Set).List()[0].(map[string]interface{})
+		payload["recurrence"] = map[string]interface{}{
+			"unit":  recurrence["unit"].(string),
+			"value": recurrence["value"].(int),
+		}
+	}
+
+	if filters := getAlertMutingRuleFilters(d); len(filters) > 0 {
+		payload["filters"] = filters
+	}
+
+	return json.Marshal(payload)
+}
+
+/*
+  Muted detectors are sent as a single filter on the sf_detectorId property, next to the dimension filters.
+  SignalFx ANDs the filters, so the detector IDs are a list (an OR), like the values of the dimension filters.
+*/
+func getAlertMutingRuleFilters(d *schema.ResourceData) []map[string]interface{} {
+	filter_list := make([]map[string]interface{}, 0)
+	if val, ok := d.GetOk("detectors"); ok {
+		detectors := []string{}
+		for _, detector := range val.([]interface{}) {
+			detectors = append(detectors, detector.(string))
+		}
+		item := make(map[string]interface{})
+		item["property"] = "sf_detectorId"
+		item["propertyValue"] = detectors
+		item["NOT"] = false
+		filter_list = append(filter_list, item)
+	}
+
+	filters := d.Get("filter").(*schema.Set).List()
+	for _, filter := range filters {
+		filter := filter.(map[string]interface{})
+		item := make(map[string]interface{})
+
+		item["property"] = filter["property"].(string)
+		item["propertyValue"] = filter["values"].(*schema.Set).List()
+		item["NOT"] = filter["negated"].(bool)
+
+		filter_list = append(filter_list, item)
+	}
+	return filter_list
+}
+
+func alertmutingruleCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	payload, err := getPayloadAlertMutingRule(d)
+	if err != nil {
+		return fmt.Errorf("Failed creating json payload: %s", err.Error())
+	}
+
+	return resourceCreate(ALERT_MUTING_RULE_API_URL, config.AuthToken, payload, d)
+}
+
+func alertmutingruleRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	url := fmt.Sprintf("%s/%s", ALERT_MUTING_RULE_API_URL, d.Id())
+
+	return resourceRead(url, config.AuthToken, d)
+}
+
+func alertmutingruleUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	payload, err := getPayloadAlertMutingRule(d)
+	if err != nil {
+		return fmt.Errorf("Failed creating json payload: %s", err.Error())
+	}
+	url := fmt.Sprintf("%s/%s", ALERT_MUTING_RULE_API_URL, d.Id())
+
+	return resourceUpdate(url, config.AuthToken, payload, d)
+}
+
+func alertmutingruleDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	url := fmt.Sprintf("%s/%s", ALERT_MUTING_RULE_API_URL, d.Id())
+	return resourceDelete(url, config.AuthToken, d)
+}
+
+/*
+  Validates the recurrence unit against a list of allowed words.
+*/
+func validateRecurrenceUnit(v interface{}, k string) (we []string, errors []error) {
+	value := v.(string)
+	allowedWords := []string{"d", "w"}
+	for _, word := range allowedWords {
+		if value == word {
+			return
+		}
+	}
+	errors = append(errors, fmt.Errorf("%s not allowed; must be one of: %s", value, strings.Join(allowedWords, ", ")))
+	return
+}
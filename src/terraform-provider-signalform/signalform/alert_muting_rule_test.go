@@ -0,0 +1,92 @@
+package signalform
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateRecurrenceUnitAllowed(t *testing.T) {
+	for _, value := range []string{"d", "w"} {
+		_, errors := validateRecurrenceUnit(value, "unit")
+		assert.Equal(t, len(errors), 0)
+	}
+}
+
+func TestValidateRecurrenceUnitNotAllowed(t *testing.T) {
+	_, errors := validateRecurrenceUnit("m", "unit")
+	assert.Equal(t, len(errors), 1)
+}
+
+func TestGetPayloadAlertMutingRule(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, alertMutingRuleResource().Schema, map[string]interface{}{
+		"description": "Weekly maintenance",
+		"start_time":  1546300800,
+		"stop_time":   1546304400,
+		"recurrence": []interface{}{
+			map[string]interface{}{"unit": "w", "value": 1},
+		},
+	})
+	payload, err := getPayloadAlertMutingRule(d)
+	assert.Nil(t, err)
+
+	mapped_payload := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(payload, &mapped_payload))
+	assert.Equal(t, map[string]interface{}{
+		"description": "Weekly maintenance",
+		"startTime":   1546300800000.0,
+		"stopTime":    1546304400000.0,
+		"recurrence":  map[string]interface{}{"unit": "w", "value": 1.0},
+	}, mapped_payload)
+}
+
+func TestGetPayloadAlertMutingRuleStopBeforeStart(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, alertMutingRuleResource().Schema, map[string]interface{}{
+		"description": "Weekly maintenance",
+		"start_time":  1546300800,
+		"stop_time":   1546300800,
+	})
+	_, err := getPayloadAlertMutingRule(d)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "stop_time (1546300800) must be after start_time (1546300800)")
+}
+
+func TestGetAlertMutingRuleFilters(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, alertMutingRuleResource().Schema, map[string]interface{}{
+		"description": "Maintenance",
+		"start_time":  1546300800,
+		"detectors":   []interface{}{"detectorA", "detectorB"},
+		"filter": []interface{}{
+			map[string]interface{}{
+				"property": "cluster",
+				"values":   []interface{}{"clusterA"},
+				"negated":  true,
+			},
+		},
+	})
+
+	expected := []map[string]interface{}{
+		map[string]interface{}{
+			"property":      "sf_detectorId",
+			"propertyValue": []string{"detectorA", "detectorB"},
+			"NOT":           false,
+		},
+		map[string]interface{}{
+			"property":      "cluster",
+			"propertyValue": []interface{}{"clusterA"},
+			"NOT":           true,
+		},
+	}
+	filters := getAlertMutingRuleFilters(d)
+	assert.Equal(t, expected, filters)
+
+	// SignalFx ANDs the filters, so all the detectors must be in a single filter
+	detectorFilters := 0
+	for _, filter := range filters {
+		if filter["property"] == "sf_detectorId" {
+			detectorFilters++
+		}
+	}
+	assert.Equal(t, 1, detectorFilters)
+}
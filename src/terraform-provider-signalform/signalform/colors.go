@@ -0,0 +1,161 @@
+package signalform
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type chartColor struct {
+	name string
+	hex  string
+}
+
+/*
+  Palette used by color scales and color ranges. Its first 16 colors are the same as the ones of the plot palette.
+*/
+var ChartColorsSlice = []chartColor{
+	{"gray", "#999999"},
+	{"blue", "#0077c2"},
+	{"light_blue", "#00b9ff"},
+	{"navy", "#6ca2b7"},
+	{"dark_orange", "#b04600"},
+	{"orange", "#f47e00"},
+	{"dark_yellow", "#e5b312"},
+	{"magenta", "#bd468d"},
+	{"cerise", "#e9008a"},
+	{"pink", "#ff8dd1"},
+	{"violet", "#876ff3"},
+	{"lilac", "#a747ff"},
+	{"gray_blue", "#ab99bc"},
+	{"dark_green", "#007c1d"},
+	{"green", "#05ce00"},
+	{"aquamarine", "#0dba8f"},
+	{"red", "#ea1849"},
+	{"light_yellow", "#eac24b"},
+	{"vivid_yellow", "#e5e517"},
+	{"light_green", "#acef7f"},
+	{"lime_green", "#6bd37e"},
+}
+
+/*
+  Palette used by plots (viz_options, event_options, histogram_options). A name means the same color in both palettes.
+*/
+var PaletteColors = map[string]int{
+	"gray":        0,
+	"blue":        1,
+	"azure":       2,
+	"navy":        3,
+	"brown":       4,
+	"orange":      5,
+	"dark_yellow": 6,
+	"magenta":     7,
+	"cerise":      8,
+	"pink":        9,
+	"violet":      10,
+	"lilac":       11,
+	"iris":        12,
+	"emerald":     13,
+	"green":       14,
+	"aquamarine":  15,
+}
+
+/*
+  Names that used to mean a different color in plots and in color scales, with the names to use instead
+*/
+var ambiguousColorNames = map[string]string{
+	"purple": "cerise (#e9008a) or lilac (#a747ff)",
+	"yellow": "dark_yellow (#e5b312) or light_yellow (#eac24b)",
+}
+
+var hexColorRegexp = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+/*
+  Get the hex code of a color, given either as a hex code or as the name of a color of one of the palettes
+*/
+func getColorHex(color string) (string, bool) {
+	if hexColorRegexp.MatchString(color) {
+		return strings.ToLower(color), true
+	}
+	for _, item := range ChartColorsSlice {
+		if color == item.name {
+			return item.hex, true
+		}
+	}
+	if index, ok := PaletteColors[color]; ok {
+		return ChartColorsSlice[index].hex, true
+	}
+	return "", false
+}
+
+/*
+  Get the index of a color in the plot palette. Hex codes and colors of the other palette are mapped to the nearest color.
+*/
+func getPlotPaletteIndex(color string) int {
+	if index, ok := PaletteColors[color]; ok {
+		return index
+	}
+	return getNearestColorIndex(color, ChartColorsSlice[:len(PaletteColors)])
+}
+
+/*
+  Get the index of a color in the color scale palette. Hex codes and colors of the other palette are mapped to the nearest color.
+*/
+func getScalePaletteIndex(color string) int {
+	for index, item := range ChartColorsSlice {
+		if color == item.name {
+			return index
+		}
+	}
+	return getNearestColorIndex(color, ChartColorsSlice)
+}
+
+func getNearestColorIndex(color string, palette []chartColor) int {
+	hex, ok := getColorHex(color)
+	if !ok {
+		return 0
+	}
+	r, g, b := getColorRGB(hex)
+	nearest := 0
+	minDistance := math.MaxFloat64
+	for index, item := range palette {
+		pr, pg, pb := getColorRGB(item.hex)
+		distance := math.Pow(r-pr, 2) + math.Pow(g-pg, 2) + math.Pow(b-pb, 2)
+		if distance < minDistance {
+			minDistance = distance
+			nearest = index
+		}
+	}
+	return nearest
+}
+
+func getColorRGB(hex string) (float64, float64, float64) {
+	value, _ := strconv.ParseUint(hex[1:], 16, 32)
+	return float64(value >> 16 & 0xff), float64(value >> 8 & 0xff), float64(value & 0xff)
+}
+
+/*
+  Validates a color, given either as the name of a color of one of the palettes or as a hex code (e.g. #ff0000).
+*/
+func validateChartColor(v interface{}, k string) (we []string, errors []error) {
+	value := v.(string)
+	if names, ok := ambiguousColorNames[value]; ok {
+		errors = append(errors, fmt.Errorf("%s not allowed, as it meant a different color in plots and in color scales; use %s, or a hex code", value, names))
+	} else if _, ok := getColorHex(value); !ok {
+		keys := make([]string, 0, len(ChartColorsSlice)+len(PaletteColors))
+		for _, item := range ChartColorsSlice {
+			keys = append(keys, item.name)
+		}
+		for name := range PaletteColors {
+			if !stringInSlice(name, keys) {
+				keys = append(keys, name)
+			}
+		}
+		sort.Strings(keys)
+		errors = append(errors, fmt.Errorf("%s not allowed; must be a hex code (e.g. #ff0000) or either %s", value, strings.Join(keys, ",")))
+	}
+	return
+}
@@ -0,0 +1,73 @@
+package signalform
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateChartColor(t *testing.T) {
+	for _, value := range []string{"blue", "azure", "vivid_yellow", "#FF0000", "#00b9ff"} {
+		_, errors := validateChartColor(value, "color")
+		assert.Equal(t, 0, len(errors))
+	}
+	for _, value := range []string{"whatever", "#ff00", "ff0000"} {
+		_, errors := validateChartColor(value, "color")
+		assert.Equal(t, 1, len(errors))
+	}
+}
+
+func TestValidateChartColorAmbiguous(t *testing.T) {
+	_, errors := validateChartColor("purple", "color")
+	assert.Equal(t, 1, len(errors))
+	assert.Contains(t, errors[0].Error(), "use cerise (#e9008a) or lilac (#a747ff)")
+	_, errors = validateChartColor("yellow", "color")
+	assert.Equal(t, 1, len(errors))
+	assert.Contains(t, errors[0].Error(), "use dark_yellow (#e5b312) or light_yellow (#eac24b)")
+}
+
+func TestChartColorsAreUnique(t *testing.T) {
+	hexes := make(map[string]string)
+	for _, item := range ChartColorsSlice {
+		_, ok := hexes[item.hex]
+		assert.False(t, ok, "%s has the same hex code as %s", item.name, hexes[item.hex])
+		hexes[item.hex] = item.name
+	}
+}
+
+func TestGetColorHex(t *testing.T) {
+	hex, _ := getColorHex("#FF0000")
+	assert.Equal(t, "#ff0000", hex)
+	hex, _ = getColorHex("light_blue")
+	assert.Equal(t, "#00b9ff", hex)
+	hex, _ = getColorHex("azure")
+	assert.Equal(t, "#00b9ff", hex)
+	_, ok := getColorHex("whatever")
+	assert.False(t, ok)
+}
+
+func TestGetPlotPaletteIndex(t *testing.T) {
+	assert.Equal(t, 8, getPlotPaletteIndex("cerise"))
+	assert.Equal(t, 2, getPlotPaletteIndex("light_blue"))
+	assert.Equal(t, 14, getPlotPaletteIndex("#00ff00"))
+	assert.Equal(t, 8, getPlotPaletteIndex("red"))
+}
+
+func TestGetScalePaletteIndex(t *testing.T) {
+	assert.Equal(t, 11, getScalePaletteIndex("lilac"))
+	assert.Equal(t, 2, getScalePaletteIndex("azure"))
+	assert.Equal(t, 16, getScalePaletteIndex("#ff1a4a"))
+	assert.Equal(t, 18, getScalePaletteIndex("#ffff00"))
+}
+
+func TestSharedColorNames(t *testing.T) {
+	// A name means the same color in plots and in color scales
+	for name, index := range PaletteColors {
+		hex, _ := getColorHex(name)
+		assert.Equal(t, ChartColorsSlice[index].hex, hex, name)
+		assert.Equal(t, index, getScalePaletteIndex(name), name)
+	}
+	for name := range ambiguousColorNames {
+		_, ok := getColorHex(name)
+		assert.False(t, ok, name)
+	}
+}
@@ -63,20 +63,29 @@ func dashboardResource() *schema.Resource {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ValidateFunc:  validateSignalfxRelativeTime,
-				Description:   "From when to display data. SignalFx time syntax (e.g. -5m, -1h)",
+				Description:   "From when to display data. SignalFx time syntax (e.g. -5m, -1h, -1h30m)",
+				ConflictsWith: []string{"start_time", "end_time"},
+			},
+			"time_range_end": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateSignalfxRelativeTime,
+				Description:   "(Now by default) Until when to display data. Requires time_range and must be after it. SignalFx time syntax (e.g. -1h)",
 				ConflictsWith: []string{"start_time", "end_time"},
 			},
 			"start_time": &schema.Schema{
-				Type:          schema.TypeInt,
+				Type:          schema.TypeString,
 				Optional:      true,
-				Description:   "Seconds since epoch to start the visualization",
-				ConflictsWith: []string{"time_range"},
+				ValidateFunc:  validateAbsoluteTime,
+				Description:   "Seconds since epoch or RFC3339 timestamp (e.g. 2018-01-01T00:00:00Z) to start the visualization",
+				ConflictsWith: []string{"time_range", "time_range_end"},
 			},
 			"end_time": &schema.Schema{
-				Type:          schema.TypeInt,
+				Type:          schema.TypeString,
 				Optional:      true,
-				Description:   "Seconds since epoch to end the visualization",
-				ConflictsWith: []string{"time_range"},
+				ValidateFunc:  validateAbsoluteTime,
+				Description:   "Seconds since epoch or RFC3339 timestamp (e.g. 2018-01-01T00:00:00Z) to end the visualization",
+				ConflictsWith: []string{"time_range", "time_range_end"},
 			},
 			"tags": &schema.Schema{
 				Type:        schema.TypeList,
@@ -290,7 +299,11 @@ func dashboardResource() *schema.Resource {
 /*
   Use Resource object to construct json payload in order to create a dashboard
 */
-func getPayloadDashboard(d *schema.ResourceData) ([]byte, error) {
+func getPayloadDashboard(d *schema.ResourceData, config *signalformConfig) ([]byte, error) {
+	if err := validateTimeRange(d); err != nil {
+		return nil, err
+	}
+
 	payload := map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
@@ -323,11 +336,7 @@ func getPayloadDashboard(d *schema.ResourceData) ([]byte, error) {
 	if chartsResolution, ok := d.GetOk("charts_resolution"); ok {
 		payload["chartDensity"] = strings.ToUpper(chartsResolution.(string))
 	}
-	if val, ok := d.GetOk("tags"); ok {
-		tags := []string{}
-		for _, tag := range val.([]interface{}) {
-			tags = append(tags, tag.(string))
-		}
+	if tags := getTags(d, config.DefaultTags); len(tags) > 0 {
 		payload["tags"] = tags
 	}
 
@@ -337,14 +346,21 @@ func getPayloadDashboard(d *schema.ResourceData) ([]byte, error) {
 func getDashboardTime(d *schema.ResourceData) map[string]interface{} {
 	timeMap := make(map[string]interface{})
 	if val, ok := d.GetOk("time_range"); ok {
-		timeMap["start"] = val.(string)
+		timeMap["start"] = normalizeRelativeTime(val.(string))
 		timeMap["end"] = "Now"
+		if val, ok := d.GetOk("time_range_end"); ok {
+			timeMap["end"] = normalizeRelativeTime(val.(string))
+		}
 	} else {
 		if val, ok := d.GetOk("start_time"); ok {
-			timeMap["start"] = val.(int) * 1000
+			if ms, err := parseAbsoluteTime(val.(string)); err == nil {
+				timeMap["start"] = ms
+			}
 		}
 		if val, ok := d.GetOk("end_time"); ok {
-			timeMap["end"] = val.(int) * 1000
+			if ms, err := parseAbsoluteTime(val.(string)); err == nil {
+				timeMap["end"] = ms
+			}
 		}
 	}
 
@@ -483,7 +499,7 @@ func getDashboardFilters(d *schema.ResourceData) []map[string]interface{} {
 
 func dashboardCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadDashboard(d)
+	payload, err := getPayloadDashboard(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
@@ -495,12 +511,12 @@ func dashboardRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
 	url := fmt.Sprintf("%s/%s", DASHBOARD_API_URL, d.Id())
 
-	return resourceRead(url, config.AuthToken, d)
+	return resourceReadTags(url, config, d)
 }
 
 func dashboardUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadDashboard(d)
+	payload, err := getPayloadDashboard(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
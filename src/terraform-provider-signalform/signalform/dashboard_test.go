@@ -1,6 +1,7 @@
 package signalform
 
 import (
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -16,3 +17,22 @@ func TestValidateChartsResolutionNotAllowed(t *testing.T) {
 	_, errors := validateChartsResolution("whatever", "charts_resolution")
 	assert.Equal(t, len(errors), 1)
 }
+
+func TestGetDashboardTime(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dashboardResource().Schema, map[string]interface{}{
+		"time_range":     "-1d12h",
+		"time_range_end": "-1h",
+	})
+	assert.Equal(t, map[string]interface{}{"start": "-36h", "end": "-1h"}, getDashboardTime(d))
+
+	d = schema.TestResourceDataRaw(t, dashboardResource().Schema, map[string]interface{}{
+		"time_range": "-15m",
+	})
+	assert.Equal(t, map[string]interface{}{"start": "-15m", "end": "Now"}, getDashboardTime(d))
+
+	d = schema.TestResourceDataRaw(t, dashboardResource().Schema, map[string]interface{}{
+		"start_time": "2018-01-01T00:00:00Z",
+		"end_time":   "2018-01-02T00:00:00Z",
+	})
+	assert.Equal(t, map[string]interface{}{"start": int64(1514764800000), "end": int64(1514851200000)}, getDashboardTime(d))
+}
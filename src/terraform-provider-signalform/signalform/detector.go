@@ -6,13 +6,18 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
+	"math"
+	"net/url"
+	"regexp"
 	"sort"
 	"strings"
+	"time"
 )
 
 const (
 	DETECTOR_API_URL = "https://api.signalfx.com/v2/detector"
 	DETECTOR_URL     = "https://app.signalfx.com/#/detector/v2/<id>/edit"
+	TEAM_API_URL     = "https://api.signalfx.com/v2/team"
 )
 
 func detectorResource() *schema.Resource {
@@ -52,8 +57,19 @@ func detectorResource() *schema.Resource {
 			},
 			"program_text": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Signalflow program text for the detector. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
+				Optional:    true,
+				Description: "Signalflow program text for the detector. Required unless a template is used. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
+			},
+			"template": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Detector template to build the detector from, i.e. the json attribute of a signalform_detector_template data source",
+			},
+			"template_vars": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Values of the [[name]] placeholders in the program text and in the rules",
 			},
 			"max_delay": &schema.Schema{
 				Type:         schema.TypeInt,
@@ -61,100 +77,264 @@ func detectorResource() *schema.Resource {
 				Description:  "How long (in seconds) to wait for late datapoints. Max value 900s (15m)",
 				ValidateFunc: validateMaxDelayValue,
 			},
-			"show_data_markers": &schema.Schema{
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "(false by default) When true, markers will be drawn for each datapoint within the visualization.",
+			"min_delay": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "How long (in seconds) to wait even if the datapoints are arriving in a timely fashion. Max value 900s (15m)",
+				ValidateFunc: validateMinDelayValue,
 			},
-			"time_range": &schema.Schema{
-				Type:          schema.TypeString,
-				Optional:      true,
-				ValidateFunc:  validateSignalfxRelativeTime,
-				Description:   "From when to display data. SignalFx time syntax (e.g. -5m, -1h)",
-				ConflictsWith: []string{"start_time", "end_time"},
+			"timezone": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTimezone,
+				Description:  "The property value is a string that denotes the geographic region associated with the time zone, (e.g. Australia/Sydney). Used by time-based SignalFlow functions such as when() with calendar windows",
 			},
-			"start_time": &schema.Schema{
-				Type:          schema.TypeInt,
-				Optional:      true,
-				ConflictsWith: []string{"time_range"},
-				Description:   "Seconds since epoch. Used for visualization",
+			"label_resolutions": &schema.Schema{
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Resolutions (in milliseconds) of the detect labels, as computed by SignalFx",
 			},
-			"end_time": &schema.Schema{
-				Type:          schema.TypeInt,
-				Optional:      true,
-				ConflictsWith: []string{"time_range"},
-				Description:   "Seconds since epoch. Used for visualization",
+			"team_policy_hash": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hash of the team policy notifications applied to the rules with notify_team_policy",
 			},
-			"tags": &schema.Schema{
-				Type:        schema.TypeList,
+			"show_data_markers": &schema.Schema{
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Description: "Tags associated with the detector",
+				Default:     false,
+				Description: "(false by default) When true, markers will be drawn for each datapoint within the visualization.",
 			},
-			"teams": &schema.Schema{
-				Type:        schema.TypeList,
+			"disable_sampling": &schema.Schema{
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Description: "Team IDs to associate the detector to",
+				Default:     false,
+				Description: "(false by default) If false, samples a subset of the output MTS, which improves UI performance",
 			},
-			"rule": &schema.Schema{
-				Type:        schema.TypeSet,
-				Required:    true,
-				Description: "Set of rules used for alerting",
+			"axis_right": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"description": &schema.Schema{
+						"min_value": &schema.Schema{
+							Type:        schema.TypeFloat,
+							Optional:    true,
+							Default:     -math.MaxFloat32,
+							Description: "The minimum value for the right axis",
+						},
+						"max_value": &schema.Schema{
+							Type:        schema.TypeFloat,
+							Optional:    true,
+							Default:     math.MaxFloat32,
+							Description: "The maximum value for the right axis",
+						},
+						"label": &schema.Schema{
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "Description of the rule",
+							Description: "Label of the right axis",
 						},
-						"notifications": &schema.Schema{
-							Type:        schema.TypeList,
+						"high_watermark": &schema.Schema{
+							Type:        schema.TypeFloat,
 							Optional:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-							Description: "List of strings specifying where notifications will be sent when an incident occurs. See https://developers.signalfx.com/v2/docs/detector-model#notifications-models for more info",
+							Default:     math.MaxFloat32,
+							Description: "A line to draw as a high watermark",
 						},
-						"severity": &schema.Schema{
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: validateSeverity,
-							Description:  "The severity of the rule, must be one of: Critical, Warning, Major, Minor, Info",
+						"high_watermark_label": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "A label to attach to the high watermark line",
 						},
-						"detect_label": &schema.Schema{
+						"low_watermark": &schema.Schema{
+							Type:        schema.TypeFloat,
+							Optional:    true,
+							Default:     -math.MaxFloat32,
+							Description: "A line to draw as a low watermark",
+						},
+						"low_watermark_label": &schema.Schema{
 							Type:        schema.TypeString,
-							Required:    true,
-							Description: "A detect label which matches a detect label within the program text",
+							Optional:    true,
+							Description: "A label to attach to the low watermark line",
 						},
-						"disabled": &schema.Schema{
-							Type:        schema.TypeBool,
+					},
+				},
+			},
+			"axis_left": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"min_value": &schema.Schema{
+							Type:        schema.TypeFloat,
 							Optional:    true,
-							Default:     false,
-							Description: "(default: false) When true, notifications and events will not be generated for the detect label",
+							Default:     -math.MaxFloat32,
+							Description: "The minimum value for the left axis",
+						},
+						"max_value": &schema.Schema{
+							Type:        schema.TypeFloat,
+							Optional:    true,
+							Default:     math.MaxFloat32,
+							Description: "The maximum value for the left axis",
 						},
-						"parameterized_body": &schema.Schema{
+						"label": &schema.Schema{
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "Custom notification message body when an alert is triggered. See https://developers.signalfx.com/v2/reference#detector-model for more info",
+							Description: "Label of the left axis",
 						},
-						"parameterized_subject": &schema.Schema{
+						"high_watermark": &schema.Schema{
+							Type:        schema.TypeFloat,
+							Optional:    true,
+							Default:     math.MaxFloat32,
+							Description: "A line to draw as a high watermark",
+						},
+						"high_watermark_label": &schema.Schema{
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "Custom notification message subject when an alert is triggered. See https://d    evelopers.signalfx.com/v2/reference#detector-model for more info",
+							Description: "A label to attach to the high watermark line",
 						},
-						"runbook_url": &schema.Schema{
+						"low_watermark": &schema.Schema{
+							Type:        schema.TypeFloat,
+							Optional:    true,
+							Default:     -math.MaxFloat32,
+							Description: "A line to draw as a low watermark",
+						},
+						"low_watermark_label": &schema.Schema{
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "URL of page to consult when an alert is triggered",
+							Description: "A label to attach to the low watermark line",
+						},
+					},
+				},
+			},
+			"viz_options": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Plot-level customization options, associated with a publish statement",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"label": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The label used in the publish statement that displays the plot (metric time series data) you want to customize",
 						},
-						"tip": &schema.Schema{
+						"display_name": &schema.Schema{
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "Plain text suggested first course of action, such as a command to execute.",
+							Description: "The name to display for this plot instead of its label",
+						},
+						"hidden": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "(false by default) Whether this plot should be published but not drawn (e.g. for helper streams)",
+						},
+						"color": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Color to use",
+							ValidateFunc: validateChartColor,
+						},
+						"axis": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateAxisTimeChart,
+							Description:  "The Y-axis associated with values for this plot. Must be either \"right\" or \"left\"",
+						},
+						"plot_type": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validatePlotTypeTimeChart,
+							Description:  "(LineChart by default) The visualization style to use. Must be \"LineChart\", \"AreaChart\", \"ColumnChart\", or \"Histogram\"",
+						},
+						"value_unit": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateUnitTimeChart,
+							Description:  "A unit to attach to this plot. Units support automatic scaling (eg thousands of bytes will be displayed as kilobytes, 90 seconds as 1.5m)",
+						},
+						"value_unit_prefix": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateValueUnitPrefix,
+							Description:  "(Metric by default) Prefix of the scaled values of value_unit. Must be \"Metric\" or \"Binary\"",
+						},
+						"value_prefix": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "An arbitrary prefix to display with the value of this plot",
+						},
+						"value_suffix": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "An arbitrary suffix to display with the value of this plot",
 						},
 					},
 				},
-				Set: resourceRuleHash,
+			},
+			"time_range": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateSignalfxRelativeTime,
+				Description:   "From when to display data. SignalFx time syntax (e.g. -5m, -1h, -1h30m)",
+				ConflictsWith: []string{"start_time", "end_time"},
+			},
+			"time_range_end": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateSignalfxRelativeTime,
+				Description:   "(Now by default) Until when to display data. Requires time_range and must be after it. SignalFx time syntax (e.g. -1h)",
+				ConflictsWith: []string{"start_time", "end_time"},
+			},
+			"start_time": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateAbsoluteTime,
+				Description:   "Seconds since epoch or RFC3339 timestamp (e.g. 2018-01-01T00:00:00Z) to start the visualization",
+				ConflictsWith: []string{"time_range", "time_range_end"},
+			},
+			"end_time": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateAbsoluteTime,
+				Description:   "Seconds since epoch or RFC3339 timestamp (e.g. 2018-01-01T00:00:00Z) to end the visualization",
+				ConflictsWith: []string{"time_range", "time_range_end"},
+			},
+			"auto_resolve_after": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateNonNegativeDuration,
+				Description:  "How long (in seconds) to wait before automatically clearing incidents whose signal has stopped reporting data. By default incidents are never auto-resolved",
+			},
+			"tags": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags associated with the detector",
+			},
+			"teams": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Team IDs to associate the detector to",
+			},
+			"authorized_writer_teams": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Team IDs that have write access to this detector. Leave empty to allow anyone to edit it",
+			},
+			"authorized_writer_users": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "User IDs that have write access to this detector. Leave empty to allow anyone to edit it",
+			},
+			"rule": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Set of rules used for alerting. Rules with the same detect label as a template rule replace it",
+				Elem:        detectorRuleResource(),
+				Set:         resourceRuleHash,
 			},
 		},
 
@@ -165,16 +345,105 @@ func detectorResource() *schema.Resource {
 	}
 }
 
+/*
+  Schema of a single alerting rule, shared by detectors and detector templates
+*/
+func detectorRuleResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the rule",
+			},
+			"notifications": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "List of strings specifying where notifications will be sent when an incident occurs. See https://developers.signalfx.com/v2/docs/detector-model#notifications-models for more info",
+			},
+			"severity": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateSeverity,
+				Description:  "The severity of the rule, must be one of: Critical, Warning, Major, Minor, Info",
+			},
+			"detect_label": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "A detect label which matches a detect label within the program text",
+			},
+			"disabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "(default: false) When true, notifications and events will not be generated for the detect label",
+			},
+			"parameterized_body": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateNotificationTemplate,
+				Description:  "Custom notification message body when an alert is triggered. See https://developers.signalfx.com/v2/reference#detector-model for more info",
+			},
+			"parameterized_subject": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateNotificationTemplate,
+				Description:  "Custom notification message subject when an alert is triggered. See https://d    evelopers.signalfx.com/v2/reference#detector-model for more info",
+			},
+			"runbook_url": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRunbookUrl,
+				Description:  "URL of page to consult when an alert is triggered. Can contain {{dimensions.*}} placeholders for dimensions the program groups by",
+			},
+			"tip": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Plain text suggested first course of action, such as a command to execute.",
+			},
+			"notify_team_policy": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "(false by default) When true, also notify the notification policy of the detector teams for the severity of the rule",
+			},
+			"reminder_interval": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateNonNegativeDuration,
+				Description:  "How often (in seconds) to re-send the notifications while the incident is still active. Reminders are disabled by default",
+			},
+			"reminder_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "TIMEOUT",
+				ValidateFunc: validateReminderType,
+				Description:  "(TIMEOUT by default) The type of reminder. Only used when reminder_interval is set. Must be \"TIMEOUT\"",
+			},
+		},
+	}
+}
+
 /*
   Use Resource object to construct json payload in order to create a detector
 */
-func getPayloadDetector(d *schema.ResourceData) ([]byte, error) {
+func getPayloadDetector(d *schema.ResourceData, config *signalformConfig) ([]byte, error) {
 
-	tf_rules := d.Get("rule").(*schema.Set).List()
+	programText, tf_rules, err := getDetectorProgramAndRules(d)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRulesDimensions(programText, tf_rules); err != nil {
+		return nil, err
+	}
+	if err := validateTimeRange(d); err != nil {
+		return nil, err
+	}
 	rules_list := make([]map[string]interface{}, len(tf_rules))
+	var teamPolicies map[string][]map[string]interface{}
 
 	for i, tf_rule := range tf_rules {
-		tf_rule := tf_rule.(map[string]interface{})
 		item := make(map[string]interface{})
 
 		item["description"] = tf_rule["description"].(string)
@@ -198,18 +467,37 @@ func getPayloadDetector(d *schema.ResourceData) ([]byte, error) {
 			item["tip"] = val.(string)
 		}
 
+		if val, ok := tf_rule["reminder_interval"]; ok && val.(int) > 0 {
+			item["reminderNotification"] = map[string]interface{}{
+				"interval": val.(int) * 1000,
+				"type":     tf_rule["reminder_type"].(string),
+			}
+		}
+
 		if notifications, ok := tf_rule["notifications"]; ok {
 			notify := getNotifications(notifications.([]interface{}))
 			item["notifications"] = notify
 		}
 
+		if val, ok := tf_rule["notify_team_policy"]; ok && val.(bool) {
+			// Team policies are only fetched once, and only if a rule needs them
+			if teamPolicies == nil {
+				teamPolicies, err = getTeamNotificationPolicies(d, TEAM_API_URL, config.AuthToken)
+				if err != nil {
+					return nil, err
+				}
+			}
+			notify, _ := item["notifications"].([]map[string]interface{})
+			item["notifications"] = mergeNotifications(notify, getTeamPolicyNotifications(teamPolicies, item["severity"].(string)))
+		}
+
 		rules_list[i] = item
 	}
 
 	payload := map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
-		"programText": sanitizeProgramText(d.Get("program_text").(string)),
+		"programText": sanitizeProgramText(programText),
 		"maxDelay":    nil,
 		"rules":       rules_list,
 	}
@@ -217,6 +505,15 @@ func getPayloadDetector(d *schema.ResourceData) ([]byte, error) {
 	if val, ok := d.GetOk("max_delay"); ok {
 		payload["maxDelay"] = val.(int) * 1000
 	}
+	if val, ok := d.GetOk("min_delay"); ok {
+		payload["minDelay"] = val.(int) * 1000
+	}
+	if val, ok := d.GetOk("timezone"); ok {
+		payload["timezone"] = val.(string)
+	}
+	if val, ok := d.GetOk("auto_resolve_after"); ok {
+		payload["autoResolveAfter"] = val.(int) * 1000
+	}
 
 	if viz := getVisualizationOptionsDetector(d); len(viz) > 0 {
 		payload["visualizationOptions"] = viz
@@ -230,38 +527,55 @@ func getPayloadDetector(d *schema.ResourceData) ([]byte, error) {
 		payload["teams"] = teams
 	}
 
-	if val, ok := d.GetOk("tags"); ok {
-		tags := []string{}
-		for _, tag := range val.([]interface{}) {
-			tags = append(tags, tag.(string))
-		}
+	if tags := getTags(d, config.DefaultTags); len(tags) > 0 {
 		payload["tags"] = tags
 	}
 
+	if authorizedWriters := getAuthorizedWritersDetector(d); len(authorizedWriters) > 0 {
+		payload["authorizedWriters"] = authorizedWriters
+	}
+
 	return json.Marshal(payload)
 }
 
+/*
+  Get the teams and users allowed to modify the detector. SignalFx treats a missing list as "anyone can edit".
+*/
+func getAuthorizedWritersDetector(d *schema.ResourceData) map[string]interface{} {
+	authorizedWriters := make(map[string]interface{})
+	if val, ok := d.GetOk("authorized_writer_teams"); ok {
+		teams := []string{}
+		for _, team := range val.([]interface{}) {
+			teams = append(teams, team.(string))
+		}
+		authorizedWriters["teams"] = teams
+	}
+	if val, ok := d.GetOk("authorized_writer_users"); ok {
+		users := []string{}
+		for _, user := range val.([]interface{}) {
+			users = append(users, user.(string))
+		}
+		authorizedWriters["users"] = users
+	}
+	return authorizedWriters
+}
+
 func getVisualizationOptionsDetector(d *schema.ResourceData) map[string]interface{} {
 	viz := make(map[string]interface{})
 	if val, ok := d.GetOk("show_data_markers"); ok {
 		viz["showDataMarkers"] = val.(bool)
 	}
-
-	timeMap := make(map[string]interface{})
-	if val, ok := d.GetOk("time_range"); ok {
-		if ms, err := fromRangeToMilliSeconds(val.(string)); err == nil {
-			timeMap["range"] = ms
-			timeMap["type"] = "relative"
-		}
+	if val, ok := d.GetOk("disable_sampling"); ok {
+		viz["disableSampling"] = val.(bool)
 	}
-	if val, ok := d.GetOk("start_time"); ok {
-		timeMap["type"] = "absolute"
-		timeMap["start"] = val.(int) * 1000
-		if val, ok := d.GetOk("end_time"); ok {
-			timeMap["end"] = val.(int) * 1000
-		}
+	if axesOptions := getAxesOptions(d); len(axesOptions) > 0 {
+		viz["axes"] = axesOptions
+	}
+	if vizOptions := getPerSignalVizOptions(d); len(vizOptions) > 0 {
+		viz["publishLabelOptions"] = vizOptions
 	}
-	if len(timeMap) > 0 {
+
+	if timeMap := getTimeOptions(d); len(timeMap) > 0 {
 		viz["time"] = timeMap
 	}
 	return viz
@@ -297,32 +611,114 @@ func getNotifications(tf_notifications []interface{}) []map[string]interface{} {
 	return notifications_list
 }
 
+/*
+  Get the notification lists of the detector teams, merged by severity (critical, major, ..., and default)
+*/
+func getTeamNotificationPolicies(d *schema.ResourceData, teamApiUrl string, sfxToken string) (map[string][]map[string]interface{}, error) {
+	teams, ok := d.GetOk("teams")
+	if !ok {
+		return nil, fmt.Errorf("notify_team_policy is set but the detector has no teams")
+	}
+
+	policies := make(map[string][]map[string]interface{})
+	for _, team := range teams.([]interface{}) {
+		url := fmt.Sprintf("%s/%s", teamApiUrl, team.(string))
+		status_code, resp_body, err := sendRequest("GET", url, sfxToken, nil)
+		if err != nil {
+			return nil, err
+		}
+		if status_code != 200 {
+			return nil, fmt.Errorf("For the team %s SignalFx returned status %d: \n%s", team, status_code, resp_body)
+		}
+
+		mapped_resp := struct {
+			NotificationLists map[string][]map[string]interface{} `json:"notificationLists"`
+		}{}
+		err = json.Unmarshal(resp_body, &mapped_resp)
+		if err != nil {
+			return nil, fmt.Errorf("Failed unmarshaling the team %s: %s", team, err.Error())
+		}
+		for severity, notifications := range mapped_resp.NotificationLists {
+			policies[severity] = mergeNotifications(policies[severity], notifications)
+		}
+	}
+	return policies, nil
+}
+
+/*
+  Get the team notifications for a rule severity, falling back on the default list of the teams
+*/
+func getTeamPolicyNotifications(policies map[string][]map[string]interface{}, severity string) []map[string]interface{} {
+	if notifications, ok := policies[strings.ToLower(severity)]; ok && len(notifications) > 0 {
+		return notifications
+	}
+	return policies["default"]
+}
+
+/*
+  Append the notifications of others that are not already in notifications
+*/
+func mergeNotifications(notifications []map[string]interface{}, others []map[string]interface{}) []map[string]interface{} {
+	merged := append([]map[string]interface{}{}, notifications...)
+	seen := make(map[string]bool)
+	for _, notification := range merged {
+		key, _ := json.Marshal(notification)
+		seen[string(key)] = true
+	}
+	for _, notification := range others {
+		key, _ := json.Marshal(notification)
+		if !seen[string(key)] {
+			seen[string(key)] = true
+			merged = append(merged, notification)
+		}
+	}
+	return merged
+}
+
 func detectorCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadDetector(d)
+	payload, err := getPayloadDetector(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
 
-	return resourceCreate(DETECTOR_API_URL, config.AuthToken, payload, d)
+	err = resourceCreate(DETECTOR_API_URL, config.AuthToken, payload, d)
+	if err != nil {
+		return err
+	}
+	// The team policies were just applied, the read stores their hash
+	d.Set("team_policy_hash", "")
+	// label_resolutions is computed by SignalFx, read it back
+	return detectorRead(d, meta)
 }
 
 func detectorRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
 	url := fmt.Sprintf("%s/%s", DETECTOR_API_URL, d.Id())
 
-	return resourceRead(url, config.AuthToken, d)
+	mapped_resp, err := resourceReadResponse(url, config.AuthToken, d)
+	if err != nil || mapped_resp == nil {
+		return err
+	}
+	d.Set("label_resolutions", getLabelResolutionsDetector(mapped_resp))
+	readTags(d, mapped_resp, config.DefaultTags)
+	return readTeamPolicyHashDetector(d, TEAM_API_URL, config.AuthToken)
 }
 
 func detectorUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadDetector(d)
+	payload, err := getPayloadDetector(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
 	url := fmt.Sprintf("%s/%s", DETECTOR_API_URL, d.Id())
 
-	return resourceUpdate(url, config.AuthToken, payload, d)
+	err = resourceUpdate(url, config.AuthToken, payload, d)
+	if err != nil {
+		return err
+	}
+	d.Set("team_policy_hash", "")
+	return detectorRead(d, meta)
 }
 
 func detectorDelete(d *schema.ResourceData, meta interface{}) error {
@@ -332,6 +728,66 @@ func detectorDelete(d *schema.ResourceData, meta interface{}) error {
 	return resourceDelete(url, config.AuthToken, d)
 }
 
+/*
+  Team policies are resolved into the rule notifications when the detector is created or updated. Compare the
+  hash of the current policies with the one of the applied policies: when a policy changed, the detector is no
+  longer synced, which shows up in terraform plan.
+*/
+func readTeamPolicyHashDetector(d *schema.ResourceData, teamApiUrl string, sfxToken string) error {
+	severities := []string{}
+	for _, rule := range d.Get("rule").(*schema.Set).List() {
+		tf_rule := rule.(map[string]interface{})
+		if val, ok := tf_rule["notify_team_policy"]; ok && val.(bool) {
+			severities = append(severities, tf_rule["severity"].(string))
+		}
+	}
+	if len(severities) == 0 {
+		d.Set("team_policy_hash", "")
+		return nil
+	}
+
+	policies, err := getTeamNotificationPolicies(d, teamApiUrl, sfxToken)
+	if err != nil {
+		return err
+	}
+	hash := getTeamPolicyHash(policies, severities)
+	if applied := d.Get("team_policy_hash").(string); applied != "" && applied != hash {
+		// Keep the applied hash, so that the detector stays out of sync until it is updated
+		d.Set("synced", false)
+		return nil
+	}
+	d.Set("team_policy_hash", hash)
+	return nil
+}
+
+/*
+  Hash the team notifications of the given severities, i.e. the ones added to the rules
+*/
+func getTeamPolicyHash(policies map[string][]map[string]interface{}, severities []string) string {
+	notifications := make(map[string][]map[string]interface{})
+	for _, severity := range severities {
+		notifications[severity] = getTeamPolicyNotifications(policies, severity)
+	}
+	// json sorts the map keys, so the hash doesn't depend on the order of the rules
+	notificationsJson, _ := json.Marshal(notifications)
+	return fmt.Sprintf("%d", hashcode.String(string(notificationsJson)))
+}
+
+/*
+  Convert the labelResolutions returned by SignalFx (detect label -> resolution in ms) to a map suitable for the state
+*/
+func getLabelResolutionsDetector(mapped_resp map[string]interface{}) map[string]interface{} {
+	labelResolutions := make(map[string]interface{})
+	if val, ok := mapped_resp["labelResolutions"].(map[string]interface{}); ok {
+		for label, resolution := range val {
+			if resolution, ok := resolution.(float64); ok {
+				labelResolutions[label] = int(resolution)
+			}
+		}
+	}
+	return labelResolutions
+}
+
 /*
    Hashing function for rule substructure of the detector resource, used in determining state changes.
 */
@@ -352,6 +808,16 @@ func resourceRuleHash(v interface{}) int {
 		}
 	}
 
+	if val, ok := m["notify_team_policy"]; ok && val.(bool) {
+		buf.WriteString("notify_team_policy-")
+	}
+
+	// Reminders are only hashed when enabled, so that rules without reminders keep their hash
+	if val, ok := m["reminder_interval"]; ok && val.(int) > 0 {
+		buf.WriteString(fmt.Sprintf("%d-", val))
+		buf.WriteString(fmt.Sprintf("%s-", m["reminder_type"]))
+	}
+
 	// Sort the notifications so that we generate a consistent hash
 	if v, ok := m["notifications"]; ok {
 		notifications := v.([]interface{})
@@ -383,3 +849,85 @@ func validateSeverity(v interface{}, k string) (we []string, errors []error) {
 	errors = append(errors, fmt.Errorf("%s not allowed; must be one of: %s", value, strings.Join(allowedWords, ", ")))
 	return
 }
+
+/*
+  Validates the reminder_type field against a list of allowed words.
+*/
+func validateReminderType(v interface{}, k string) (we []string, errors []error) {
+	value := v.(string)
+	allowedWords := []string{"TIMEOUT"}
+	for _, word := range allowedWords {
+		if value == word {
+			return
+		}
+	}
+	errors = append(errors, fmt.Errorf("%s not allowed; must be one of: %s", value, strings.Join(allowedWords, ", ")))
+	return
+}
+
+/*
+  Validates that timezone is a name of the IANA time zone database (e.g. Europe/Paris)
+*/
+func validateTimezone(v interface{}, k string) (we []string, errors []error) {
+	value := v.(string)
+	if _, err := time.LoadLocation(value); err != nil || value == "Local" {
+		errors = append(errors, fmt.Errorf("%s not allowed; must be a time zone name (e.g. Europe/Paris)", value))
+	}
+	return
+}
+
+/*
+  Validates that runbook_url is an absolute http(s) URL. Handlebars and template placeholders are allowed anywhere.
+*/
+func validateRunbookUrl(v interface{}, k string) (we []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+	sample := notificationTagRegexp.ReplaceAllString(value, "placeholder")
+	sample = templatePlaceholderRegexp.ReplaceAllString(sample, "placeholder")
+	u, err := url.Parse(sample)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errors = append(errors, fmt.Errorf("%s not allowed; must be an absolute http or https URL", value))
+	}
+	return
+}
+
+var groupByRegexp = regexp.MustCompile(`\bby\s*=\s*(\[[^\]]*\]|'[^']*'|"[^"]*")`)
+var quotedStringRegexp = regexp.MustCompile(`'([^']*)'|"([^"]*)"`)
+var dimensionPlaceholderRegexp = regexp.MustCompile(`\{\{\{?\s*dimensions\.([^\s}]+)\s*\}?\}\}`)
+
+/*
+  Get the dimensions used in the by=[...] clauses of the program text. Returns nil if the program doesn't group by anything.
+*/
+func getProgramGroupByDimensions(programText string) []string {
+	var dimensions []string
+	for _, match := range groupByRegexp.FindAllStringSubmatch(programText, -1) {
+		for _, quoted := range quotedStringRegexp.FindAllStringSubmatch(match[1], -1) {
+			dimensions = append(dimensions, quoted[1]+quoted[2])
+		}
+	}
+	return dimensions
+}
+
+/*
+  Checks that the {{dimensions.*}} placeholders in runbook_url and tip refer to dimensions the program groups by.
+  Programs that don't group by anything keep all the dimensions of the time series, so nothing can be checked.
+*/
+func validateRulesDimensions(programText string, rules []map[string]interface{}) error {
+	dimensions := getProgramGroupByDimensions(programText)
+	if len(dimensions) == 0 {
+		return nil
+	}
+	for _, rule := range rules {
+		for _, key := range []string{"runbook_url", "tip"} {
+			val, _ := rule[key].(string)
+			for _, match := range dimensionPlaceholderRegexp.FindAllStringSubmatch(val, -1) {
+				if !stringInSlice(match[1], dimensions) {
+					return fmt.Errorf("rule %s: %s uses {{dimensions.%s}} but program_text only groups by: %s", rule["detect_label"], key, match[1], strings.Join(dimensions, ", "))
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package signalform
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func detectorEventsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"detector_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the detector to get the incidents of",
+			},
+			"include_resolved": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "(false by default) Whether to also return the incidents that have been resolved",
+			},
+			"limit": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     100,
+				Description: "(100 by default) Maximum number of incidents to return, most recent first",
+			},
+			"incidents": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Incidents of the detector",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"incident_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the incident",
+						},
+						"detect_label": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Detect label of the rule that triggered the incident",
+						},
+						"severity": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Severity of the incident",
+						},
+						"state": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "State of the incident (e.g. ANOMALOUS, OK, STOPPED, MANUALLY_RESOLVED)",
+						},
+						"active": &schema.Schema{
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the incident is still active",
+						},
+						"triggered_at": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Seconds since epoch at which the incident was triggered",
+						},
+						"updated_at": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Seconds since epoch of the latest event of the incident",
+						},
+						"dimensions": &schema.Schema{
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Dimensions of the time series that triggered the incident",
+						},
+					},
+				},
+			},
+			"active_incident_counts": &schema.Schema{
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Number of active incidents per severity",
+			},
+		},
+
+		Read: detectorEventsRead,
+	}
+}
+
+func detectorEventsRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	detectorId := d.Get("detector_id").(string)
+	url := fmt.Sprintf("%s/%s/incidents?includeResolved=%t&limit=%d", DETECTOR_API_URL, detectorId, d.Get("include_resolved").(bool), d.Get("limit").(int))
+
+	status_code, resp_body, err := sendRequest("GET", url, config.AuthToken, nil)
+	if err != nil {
+		return err
+	}
+	if status_code != 200 {
+		return fmt.Errorf("For the incidents of detector %s SignalFx returned status %d: \n%s", detectorId, status_code, resp_body)
+	}
+
+	mapped_resp := []map[string]interface{}{}
+	err = json.Unmarshal(resp_body, &mapped_resp)
+	if err != nil {
+		return fmt.Errorf("Failed unmarshaling the incidents of detector %s: %s", detectorId, err.Error())
+	}
+
+	incidents := getDetectorIncidents(mapped_resp)
+	activeCounts := make(map[string]interface{})
+	for _, incident := range incidents {
+		if incident["active"].(bool) {
+			severity := incident["severity"].(string)
+			if count, ok := activeCounts[severity]; ok {
+				activeCounts[severity] = count.(int) + 1
+			} else {
+				activeCounts[severity] = 1
+			}
+		}
+	}
+
+	d.SetId(detectorId)
+	d.Set("incidents", incidents)
+	d.Set("active_incident_counts", activeCounts)
+	return nil
+}
+
+/*
+  Convert the incidents returned by SignalFx to a list of maps suitable for the state. The dimensions and the
+  timestamps come from the events of the incident, the first one being the one that triggered it.
+*/
+func getDetectorIncidents(mapped_resp []map[string]interface{}) []map[string]interface{} {
+	incidents := make([]map[string]interface{}, len(mapped_resp))
+	for i, tf_incident := range mapped_resp {
+		item := make(map[string]interface{})
+		item["incident_id"], _ = tf_incident["incidentId"].(string)
+		item["detect_label"], _ = tf_incident["detectLabel"].(string)
+		item["severity"], _ = tf_incident["severity"].(string)
+		item["state"], _ = tf_incident["anomalyState"].(string)
+		item["active"], _ = tf_incident["active"].(bool)
+
+		dimensions := make(map[string]interface{})
+		if events, ok := tf_incident["events"].([]interface{}); ok && len(events) > 0 {
+			first, _ := events[0].(map[string]interface{})
+			if timestamp, ok := first["timestamp"].(float64); ok {
+				item["triggered_at"] = int(timestamp / 1000)
+			}
+			inputs, _ := first["inputs"].(map[string]interface{})
+			for _, input := range inputs {
+				input, _ := input.(map[string]interface{})
+				key, _ := input["key"].(map[string]interface{})
+				for dimension, value := range key {
+					dimensions[dimension] = fmt.Sprintf("%v", value)
+				}
+			}
+
+			last, _ := events[len(events)-1].(map[string]interface{})
+			if timestamp, ok := last["timestamp"].(float64); ok {
+				item["updated_at"] = int(timestamp / 1000)
+			}
+		}
+		item["dimensions"] = dimensions
+
+		incidents[i] = item
+	}
+	return incidents
+}
@@ -0,0 +1,41 @@
+package signalform
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetDetectorIncidents(t *testing.T) {
+	resp := `[{
+		"incidentId": "EEE",
+		"detectLabel": "Processing old messages 30m",
+		"severity": "Critical",
+		"anomalyState": "ANOMALOUS",
+		"active": true,
+		"events": [
+			{"timestamp": 1546300800000, "inputs": {"_S1": {"key": {"cluster": "clusterA", "sf_metric": "app.delay"}, "value": 75}}},
+			{"timestamp": 1546300860000, "inputs": {"_S1": {"key": {"cluster": "clusterA", "sf_metric": "app.delay"}, "value": 80}}}
+		]
+	}]`
+	mapped_resp := []map[string]interface{}{}
+	err := json.Unmarshal([]byte(resp), &mapped_resp)
+	assert.Nil(t, err)
+
+	expected := []map[string]interface{}{
+		map[string]interface{}{
+			"incident_id":  "EEE",
+			"detect_label": "Processing old messages 30m",
+			"severity":     "Critical",
+			"state":        "ANOMALOUS",
+			"active":       true,
+			"triggered_at": 1546300800,
+			"updated_at":   1546300860,
+			"dimensions": map[string]interface{}{
+				"cluster":   "clusterA",
+				"sf_metric": "app.delay",
+			},
+		},
+	}
+	assert.Equal(t, expected, getDetectorIncidents(mapped_resp))
+}
@@ -0,0 +1,137 @@
+package signalform
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const DETECTOR_PREVIEW_API_URL = "https://stream.signalfx.com/v2/signalflow/preflight"
+
+func detectorPreviewDataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"program_text": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Signalflow program text of the detector to evaluate. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
+			},
+			"time_range": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "-1d",
+				ValidateFunc: validateSignalfxRelativeTime,
+				Description:  "(-1d by default) How far back to evaluate the program. SignalFx time syntax (e.g. -5m, -1h)",
+			},
+			"detect_labels": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Only count the events of these detect labels (usually the detect labels of the rules). All the events are counted by default",
+			},
+			"event_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "How many times the detector would have fired during time_range",
+			},
+			"event_timestamps": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Seconds since epoch at which the detector would have fired",
+			},
+		},
+
+		Read: detectorPreviewRead,
+	}
+}
+
+/*
+  Run the program text against historical data and count how many times it would have fired
+*/
+func detectorPreviewRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	programText := sanitizeProgramText(d.Get("program_text").(string))
+
+	timeRange, err := fromRangeToMilliSeconds(d.Get("time_range").(string))
+	if err != nil {
+		return fmt.Errorf("Failed parsing time_range: %s", err.Error())
+	}
+	stop := time.Now().UnixNano() / int64(time.Millisecond)
+	start := stop - int64(timeRange)
+	url := fmt.Sprintf("%s?start=%d&stop=%d", DETECTOR_PREVIEW_API_URL, start, stop)
+
+	status_code, resp_body, err := sendRequestWithContentType("POST", url, "text/plain", config.AuthToken, []byte(programText))
+	if err != nil {
+		return err
+	}
+	if status_code != 200 {
+		return fmt.Errorf("For the detector preview SignalFx returned status %d: \n%s", status_code, resp_body)
+	}
+
+	detectLabels := []string{}
+	for _, label := range d.Get("detect_labels").([]interface{}) {
+		detectLabels = append(detectLabels, label.(string))
+	}
+	timestamps, err := getDetectorPreviewEvents(resp_body, detectLabels)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(fmt.Sprintf("%s-%d-%d", programText, start, stop))))
+	d.Set("event_count", len(timestamps))
+	d.Set("event_timestamps", timestamps)
+	return nil
+}
+
+/*
+  Parse the event stream returned by the preflight endpoint and return the timestamps (seconds since epoch)
+  of the events that fired, optionally restricted to a list of detect labels
+*/
+func getDetectorPreviewEvents(resp_body []byte, detectLabels []string) ([]int, error) {
+	timestamps := []int{}
+	eventType := ""
+	scanner := bufio.NewScanner(bytes.NewReader(resp_body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "event:") {
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			continue
+		}
+		if eventType != "event" || !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		event := struct {
+			TimestampMs float64                `json:"timestampMs"`
+			Properties  map[string]interface{} `json:"properties"`
+		}{}
+		err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &event)
+		if err != nil {
+			return nil, fmt.Errorf("Failed unmarshaling the detector preview events: %s", err.Error())
+		}
+		if event.Properties["is"] != "anomalous" {
+			continue
+		}
+		if len(detectLabels) > 0 {
+			found := false
+			for _, label := range detectLabels {
+				if event.Properties["sf_detectLabel"] == label {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		timestamps = append(timestamps, int(event.TimestampMs/1000))
+	}
+	return timestamps, scanner.Err()
+}
@@ -0,0 +1,39 @@
+package signalform
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const previewStream = `event: control-message
+data: {"event": "STREAM_START"}
+
+event: event
+data: {"tsId": "AAA", "timestampMs": 1546300800000, "properties": {"is": "anomalous", "sf_detectLabel": "High latency"}}
+
+event: event
+data: {"tsId": "AAA", "timestampMs": 1546300860000, "properties": {"is": "ok", "sf_detectLabel": "High latency"}}
+
+event: event
+data: {"tsId": "BBB", "timestampMs": 1546304400000, "properties": {"is": "anomalous", "sf_detectLabel": "Low throughput"}}
+
+event: control-message
+data: {"event": "END_OF_CHANNEL"}
+`
+
+func TestGetDetectorPreviewEvents(t *testing.T) {
+	timestamps, err := getDetectorPreviewEvents([]byte(previewStream), []string{})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1546300800, 1546304400}, timestamps)
+}
+
+func TestGetDetectorPreviewEventsByDetectLabel(t *testing.T) {
+	timestamps, err := getDetectorPreviewEvents([]byte(previewStream), []string{"Low throughput"})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1546304400}, timestamps)
+}
+
+func TestGetDetectorPreviewEventsMalformed(t *testing.T) {
+	_, err := getDetectorPreviewEvents([]byte("event: event\ndata: {"), []string{})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,242 @@
+package signalform
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+/*
+  Placeholders look like [[name]], so that they don't clash with terraform interpolation (${...}) nor with
+  the handlebars variables of the notification messages ({{...}})
+*/
+var templatePlaceholderRegexp = regexp.MustCompile(`\[\[\s*([A-Za-z0-9_]+)\s*\]\]`)
+
+type detectorTemplate struct {
+	ProgramText string                   `json:"program_text"`
+	Rules       []map[string]interface{} `json:"rules"`
+}
+
+func detectorTemplateDataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"program_text": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Signalflow program text of the template. Can contain [[name]] placeholders, filled in by the template_vars of the detector",
+			},
+			"rule": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Set of rules shared by all the detectors using the template. Can contain [[name]] placeholders",
+				Elem:        detectorRuleResource(),
+				Set:         resourceRuleHash,
+			},
+			"json": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The template, to be passed to the template argument of signalform_detector",
+			},
+		},
+
+		Read: detectorTemplateRead,
+	}
+}
+
+func detectorTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	template := detectorTemplate{
+		ProgramText: d.Get("program_text").(string),
+		Rules:       []map[string]interface{}{},
+	}
+	for _, rule := range d.Get("rule").(*schema.Set).List() {
+		template.Rules = append(template.Rules, rule.(map[string]interface{}))
+	}
+
+	templateJson, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("Failed creating json for the detector template: %s", err.Error())
+	}
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(string(templateJson))))
+	d.Set("json", string(templateJson))
+	return nil
+}
+
+/*
+  Get the program text and the rules of the detector, after merging them with the template (if any) and
+  replacing the placeholders with template_vars. Placeholders are only replaced when template or template_vars
+  is set. Rules are returned in the same format as the rule set.
+*/
+func getDetectorProgramAndRules(d *schema.ResourceData) (string, []map[string]interface{}, error) {
+	programText := ""
+	templateRules := []map[string]interface{}{}
+	_, hasTemplate := d.GetOk("template")
+	_, hasVars := d.GetOk("template_vars")
+	if val, ok := d.GetOk("template"); ok {
+		template, err := parseDetectorTemplate(val.(string))
+		if err != nil {
+			return "", nil, err
+		}
+		programText = template.ProgramText
+		templateRules = template.Rules
+	}
+	if val, ok := d.GetOk("program_text"); ok {
+		programText = val.(string)
+	}
+	if programText == "" {
+		return "", nil, fmt.Errorf("program_text: required field is not set, either directly or through a template")
+	}
+
+	rules := []map[string]interface{}{}
+	for _, rule := range d.Get("rule").(*schema.Set).List() {
+		rules = append(rules, rule.(map[string]interface{}))
+	}
+
+	// Detectors that use neither a template nor template_vars are sent as written, [[...]] included
+	if hasTemplate || hasVars {
+		vars := d.Get("template_vars").(map[string]interface{})
+		var err error
+		if programText, err = renderDetectorTemplate(programText, vars); err != nil {
+			return "", nil, err
+		}
+		if templateRules, err = renderDetectorRules(templateRules, vars); err != nil {
+			return "", nil, err
+		}
+		if rules, err = renderDetectorRules(rules, vars); err != nil {
+			return "", nil, err
+		}
+	}
+
+	rules = mergeDetectorRules(templateRules, rules)
+	if len(rules) == 0 {
+		return "", nil, fmt.Errorf("rule: at least one rule is required, either directly or through a template")
+	}
+	return programText, rules, nil
+}
+
+/*
+  Parse the json attribute of a detector template. Missing rule attributes get their default value, and json
+  numbers are converted back to integers, so that the rules look like the ones coming from the rule set.
+*/
+func parseDetectorTemplate(templateJson string) (*detectorTemplate, error) {
+	template := &detectorTemplate{}
+	if err := json.Unmarshal([]byte(templateJson), template); err != nil {
+		return nil, fmt.Errorf("Failed parsing the detector template: %s", err.Error())
+	}
+
+	for _, rule := range template.Rules {
+		for key, field := range detectorRuleResource().Schema {
+			val, ok := rule[key]
+			switch field.Type {
+			case schema.TypeString:
+				if !ok || val == nil {
+					if field.Default != nil {
+						rule[key] = field.Default
+					} else {
+						rule[key] = ""
+					}
+				}
+			case schema.TypeBool:
+				if !ok || val == nil {
+					rule[key] = false
+				}
+			case schema.TypeInt:
+				if number, isNumber := val.(float64); isNumber {
+					rule[key] = int(number)
+				} else {
+					rule[key] = 0
+				}
+			case schema.TypeList:
+				if !ok || val == nil {
+					rule[key] = []interface{}{}
+				}
+			}
+		}
+	}
+	return template, nil
+}
+
+/*
+  Replace the [[name]] placeholders in text with the matching template vars. All the placeholders must have a value.
+*/
+func renderDetectorTemplate(text string, vars map[string]interface{}) (string, error) {
+	missing := make(map[string]bool)
+	rendered := templatePlaceholderRegexp.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := templatePlaceholderRegexp.FindStringSubmatch(placeholder)[1]
+		if val, ok := vars[name]; ok {
+			return fmt.Sprintf("%v", val)
+		}
+		missing[name] = true
+		return placeholder
+	})
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("No value in template_vars for: %s", strings.Join(names, ", "))
+	}
+	return rendered, nil
+}
+
+/*
+  Render every string attribute of the rules, notifications included. The rules are copied, not modified.
+*/
+func renderDetectorRules(rules []map[string]interface{}, vars map[string]interface{}) ([]map[string]interface{}, error) {
+	rendered_rules := make([]map[string]interface{}, len(rules))
+	for i, rule := range rules {
+		item := make(map[string]interface{})
+		for key, val := range rule {
+			switch val := val.(type) {
+			case string:
+				rendered, err := renderDetectorTemplate(val, vars)
+				if err != nil {
+					return nil, fmt.Errorf("rule %s: %s", key, err.Error())
+				}
+				item[key] = rendered
+			case []interface{}:
+				list := make([]interface{}, len(val))
+				for j, elem := range val {
+					if elem, ok := elem.(string); ok {
+						rendered, err := renderDetectorTemplate(elem, vars)
+						if err != nil {
+							return nil, fmt.Errorf("rule %s: %s", key, err.Error())
+						}
+						list[j] = rendered
+					} else {
+						list[j] = elem
+					}
+				}
+				item[key] = list
+			default:
+				item[key] = val
+			}
+		}
+		rendered_rules[i] = item
+	}
+	return rendered_rules, nil
+}
+
+/*
+  Rules of the detector replace the template rules with the same detect label, the others are added
+*/
+func mergeDetectorRules(templateRules []map[string]interface{}, rules []map[string]interface{}) []map[string]interface{} {
+	overridden := make(map[string]bool)
+	for _, rule := range rules {
+		overridden[rule["detect_label"].(string)] = true
+	}
+
+	merged := []map[string]interface{}{}
+	for _, rule := range templateRules {
+		if !overridden[rule["detect_label"].(string)] {
+			merged = append(merged, rule)
+		}
+	}
+	return append(merged, rules...)
+}
@@ -0,0 +1,133 @@
+package signalform
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRenderDetectorTemplate(t *testing.T) {
+	vars := map[string]interface{}{
+		"service":   "foo",
+		"threshold": "60",
+	}
+	text := "signal = data('app.delay', filter('service', '[[service]]')).max()\ndetect(when(signal > [[ threshold ]], '5m')).publish('[[service]] is slow')"
+	expected := "signal = data('app.delay', filter('service', 'foo')).max()\ndetect(when(signal > 60, '5m')).publish('foo is slow')"
+
+	rendered, err := renderDetectorTemplate(text, vars)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, rendered)
+}
+
+func TestRenderDetectorTemplateMissingVars(t *testing.T) {
+	_, err := renderDetectorTemplate("[[threshold]] [[service]] [[service]]", map[string]interface{}{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "service, threshold")
+}
+
+func TestRenderDetectorTemplateLeavesHandlebars(t *testing.T) {
+	rendered, err := renderDetectorTemplate("{{ruleName}} for {{dimensions.host}}", map[string]interface{}{})
+	assert.Nil(t, err)
+	assert.Equal(t, "{{ruleName}} for {{dimensions.host}}", rendered)
+}
+
+func TestRenderDetectorRules(t *testing.T) {
+	rules := []map[string]interface{}{
+		map[string]interface{}{
+			"detect_label":      "[[service]] is slow",
+			"notifications":     []interface{}{"Team,[[team]]"},
+			"disabled":          false,
+			"reminder_interval": 0,
+		},
+	}
+	vars := map[string]interface{}{
+		"service": "foo",
+		"team":    "ABC",
+	}
+
+	expected := []map[string]interface{}{
+		map[string]interface{}{
+			"detect_label":      "foo is slow",
+			"notifications":     []interface{}{"Team,ABC"},
+			"disabled":          false,
+			"reminder_interval": 0,
+		},
+	}
+	rendered, err := renderDetectorRules(rules, vars)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, rendered)
+	// The original rules are left untouched
+	assert.Equal(t, "[[service]] is slow", rules[0]["detect_label"])
+}
+
+func TestParseDetectorTemplate(t *testing.T) {
+	template, err := parseDetectorTemplate(`{"program_text": "detect(when(A > 1)).publish('foo')", "rules": [{"detect_label": "foo", "severity": "Critical", "reminder_interval": 3600}]}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "detect(when(A > 1)).publish('foo')", template.ProgramText)
+
+	rule := template.Rules[0]
+	assert.Equal(t, "foo", rule["detect_label"])
+	assert.Equal(t, 3600, rule["reminder_interval"])
+	assert.Equal(t, "TIMEOUT", rule["reminder_type"])
+	assert.Equal(t, "", rule["description"])
+	assert.Equal(t, false, rule["disabled"])
+	assert.Equal(t, []interface{}{}, rule["notifications"])
+}
+
+func TestParseDetectorTemplateInvalid(t *testing.T) {
+	_, err := parseDetectorTemplate("{")
+	assert.NotNil(t, err)
+}
+
+func TestMergeDetectorRules(t *testing.T) {
+	templateRules := []map[string]interface{}{
+		map[string]interface{}{"detect_label": "foo", "severity": "Warning"},
+		map[string]interface{}{"detect_label": "bar", "severity": "Warning"},
+	}
+	rules := []map[string]interface{}{
+		map[string]interface{}{"detect_label": "foo", "severity": "Critical"},
+	}
+
+	expected := []map[string]interface{}{
+		map[string]interface{}{"detect_label": "bar", "severity": "Warning"},
+		map[string]interface{}{"detect_label": "foo", "severity": "Critical"},
+	}
+	assert.Equal(t, expected, mergeDetectorRules(templateRules, rules))
+}
+
+func TestGetDetectorProgramAndRulesWithoutTemplate(t *testing.T) {
+	raw := map[string]interface{}{
+		"name":         "plain detector",
+		"program_text": "detect(when(data('[[metric]]') > 1)).publish('[[label]]')",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"detect_label": "[[label]]",
+				"severity":     "Critical",
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, detectorResource().Schema, raw)
+
+	programText, rules, err := getDetectorProgramAndRules(d)
+	assert.Nil(t, err)
+	assert.Equal(t, "detect(when(data('[[metric]]') > 1)).publish('[[label]]')", programText)
+	assert.Equal(t, "[[label]]", rules[0]["detect_label"])
+}
+
+func TestGetDetectorProgramAndRulesWithTemplateVars(t *testing.T) {
+	raw := map[string]interface{}{
+		"name":          "templated detector",
+		"program_text":  "detect(when(data('[[metric]]') > 1)).publish('[[label]]')",
+		"template_vars": map[string]interface{}{"metric": "cpu.idle"},
+		"rule": []interface{}{
+			map[string]interface{}{
+				"detect_label": "high",
+				"severity":     "Critical",
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, detectorResource().Schema, raw)
+
+	_, _, err := getDetectorProgramAndRules(d)
+	assert.EqualError(t, err, "No value in template_vars for: label")
+}
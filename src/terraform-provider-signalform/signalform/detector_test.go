@@ -1,11 +1,34 @@
 package signalform
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
+/*
+  Build the json payload of a detector from a raw configuration, with a single rule on the CPU label
+*/
+func getTestPayloadDetector(t *testing.T, raw map[string]interface{}) map[string]interface{} {
+	raw["name"] = "test"
+	raw["program_text"] = "detect(when(data('cpu.utilization') > 90)).publish('CPU')"
+	raw["rule"] = []interface{}{
+		map[string]interface{}{"severity": "Critical", "detect_label": "CPU"},
+	}
+	d := schema.TestResourceDataRaw(t, detectorResource().Schema, raw)
+
+	payload, err := getPayloadDetector(d, &signalformConfig{})
+	assert.Nil(t, err)
+	mapped_payload := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(payload, &mapped_payload))
+	return mapped_payload
+}
+
 func TestGetNotifications(t *testing.T) {
 	values := []interface{}{
 		"Email,test@yelp.com",
@@ -80,3 +103,263 @@ func TestValidateSeverityNotAllowed(t *testing.T) {
 	_, errors := validateSeverity("foo", "severity")
 	assert.Equal(t, len(errors), 1)
 }
+
+func TestGetLabelResolutionsDetector(t *testing.T) {
+	mapped_resp := map[string]interface{}{
+		"labelResolutions": map[string]interface{}{
+			"Processing old messages 5m":  1000.0,
+			"Processing old messages 30m": 60000.0,
+		},
+	}
+
+	expected := map[string]interface{}{
+		"Processing old messages 5m":  1000,
+		"Processing old messages 30m": 60000,
+	}
+	assert.Equal(t, expected, getLabelResolutionsDetector(mapped_resp))
+	assert.Equal(t, map[string]interface{}{}, getLabelResolutionsDetector(map[string]interface{}{}))
+}
+
+func TestResourceRuleHashWithReminder(t *testing.T) {
+	values := map[string]interface{}{
+		"description":       "Test Rule Name",
+		"detect_label":      "Test Detect Label",
+		"severity":          "Critical",
+		"disabled":          "true",
+		"reminder_interval": 0,
+		"reminder_type":     "TIMEOUT",
+	}
+
+	// Disabled reminders don't change the hash
+	expected := hashcode.String("Test Rule Name-Critical-Test Detect Label-true-")
+	assert.Equal(t, expected, resourceRuleHash(values))
+
+	values["reminder_interval"] = 3600
+	expected = hashcode.String("Test Rule Name-Critical-Test Detect Label-true-3600-TIMEOUT-")
+	assert.Equal(t, expected, resourceRuleHash(values))
+}
+
+func TestValidateReminderTypeAllowed(t *testing.T) {
+	_, errors := validateReminderType("TIMEOUT", "reminder_type")
+	assert.Equal(t, len(errors), 0)
+}
+
+func TestValidateReminderTypeNotAllowed(t *testing.T) {
+	_, errors := validateReminderType("foo", "reminder_type")
+	assert.Equal(t, len(errors), 1)
+}
+
+func TestValidateTimezone(t *testing.T) {
+	for _, value := range []string{"Europe/Paris", "Australia/Sydney", "UTC"} {
+		_, errors := validateTimezone(value, "timezone")
+		assert.Equal(t, 0, len(errors))
+	}
+	for _, value := range []string{"Europe/Pari", "Local", "+02:00"} {
+		_, errors := validateTimezone(value, "timezone")
+		assert.Equal(t, 1, len(errors))
+	}
+}
+
+func TestValidateRunbookUrlAllowed(t *testing.T) {
+	for _, value := range []string{"", "https://example.com/runbook", "http://wiki.example.com/{{dimensions.service}}?host={{dimensions.host}}", "https://[[wiki]]/runbook"} {
+		_, errors := validateRunbookUrl(value, "runbook_url")
+		assert.Equal(t, 0, len(errors), value)
+	}
+}
+
+func TestValidateRunbookUrlNotAllowed(t *testing.T) {
+	for _, value := range []string{"example.com/runbook", "ftp://example.com/runbook", "https://", "not a url"} {
+		_, errors := validateRunbookUrl(value, "runbook_url")
+		assert.Equal(t, 1, len(errors), value)
+	}
+}
+
+func TestGetProgramGroupByDimensions(t *testing.T) {
+	programText := "A = data('cpu.utilization').mean(by=['host', \"cluster\"])\nB = data('memory.utilization').max(by='service')\ndetect(when(A > 90)).publish('CPU')"
+	assert.Equal(t, []string{"host", "cluster", "service"}, getProgramGroupByDimensions(programText))
+	assert.Nil(t, getProgramGroupByDimensions("detect(when(data('cpu.utilization').mean() > 90)).publish('CPU')"))
+}
+
+func TestValidateRulesDimensions(t *testing.T) {
+	programText := "detect(when(data('cpu.utilization').mean(by=['host']) > 90)).publish('CPU')"
+	rules := []map[string]interface{}{
+		map[string]interface{}{
+			"detect_label": "CPU",
+			"runbook_url":  "https://example.com/{{dimensions.host}}",
+			"tip":          "ssh {{dimensions.host}}",
+		},
+	}
+	assert.Nil(t, validateRulesDimensions(programText, rules))
+
+	rules[0]["tip"] = "check {{dimensions.cluster}}"
+	err := validateRulesDimensions(programText, rules)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "{{dimensions.cluster}}")
+
+	// Without grouping all the dimensions are kept
+	assert.Nil(t, validateRulesDimensions("detect(when(data('cpu.utilization') > 90)).publish('CPU')", rules))
+}
+
+func TestGetTeamPolicyNotifications(t *testing.T) {
+	policies := map[string][]map[string]interface{}{
+		"critical": []map[string]interface{}{
+			map[string]interface{}{"type": "PagerDuty", "credentialId": "credId"},
+		},
+		"default": []map[string]interface{}{
+			map[string]interface{}{"type": "Email", "email": "test@yelp.com"},
+		},
+	}
+	assert.Equal(t, policies["critical"], getTeamPolicyNotifications(policies, "Critical"))
+	assert.Equal(t, policies["default"], getTeamPolicyNotifications(policies, "Warning"))
+}
+
+func TestGetTeamNotificationPolicies(t *testing.T) {
+	teams := map[string]string{
+		"/teamA": `{"notificationLists": {"critical": [{"type": "PagerDuty", "credentialId": "credId"}], "default": [{"type": "Email", "email": "a@yelp.com"}]}}`,
+		"/teamB": `{"notificationLists": {"critical": [{"type": "PagerDuty", "credentialId": "credId"}, {"type": "Team", "team": "teamB"}]}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-SF-Token"))
+		if team, ok := teams[r.URL.Path]; ok {
+			fmt.Fprintln(w, team)
+		} else {
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, detectorResource().Schema, map[string]interface{}{
+		"teams": []interface{}{"teamA", "teamB"},
+	})
+	policies, err := getTeamNotificationPolicies(d, server.URL, "token")
+	assert.Nil(t, err)
+
+	// Notifications shared by the teams are only sent once
+	notifications := []map[string]interface{}{
+		map[string]interface{}{"type": "Email", "email": "test@yelp.com"},
+	}
+	expected := []map[string]interface{}{
+		map[string]interface{}{"type": "Email", "email": "test@yelp.com"},
+		map[string]interface{}{"type": "PagerDuty", "credentialId": "credId"},
+		map[string]interface{}{"type": "Team", "team": "teamB"},
+	}
+	assert.Equal(t, expected, mergeNotifications(notifications, getTeamPolicyNotifications(policies, "Critical")))
+
+	expected = []map[string]interface{}{
+		map[string]interface{}{"type": "Email", "email": "test@yelp.com"},
+		map[string]interface{}{"type": "Email", "email": "a@yelp.com"},
+	}
+	assert.Equal(t, expected, mergeNotifications(notifications, getTeamPolicyNotifications(policies, "Warning")))
+
+	d = schema.TestResourceDataRaw(t, detectorResource().Schema, map[string]interface{}{
+		"teams": []interface{}{"teamA", "teamC"},
+	})
+	_, err = getTeamNotificationPolicies(d, server.URL, "token")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "For the team teamC SignalFx returned status 404")
+}
+
+func TestMergeNotifications(t *testing.T) {
+	notifications := []map[string]interface{}{
+		map[string]interface{}{"type": "Email", "email": "test@yelp.com"},
+	}
+	others := []map[string]interface{}{
+		map[string]interface{}{"type": "Email", "email": "test@yelp.com"},
+		map[string]interface{}{"type": "PagerDuty", "credentialId": "credId"},
+	}
+
+	expected := []map[string]interface{}{
+		map[string]interface{}{"type": "Email", "email": "test@yelp.com"},
+		map[string]interface{}{"type": "PagerDuty", "credentialId": "credId"},
+	}
+	assert.Equal(t, expected, mergeNotifications(notifications, others))
+	assert.Equal(t, 1, len(notifications))
+}
+
+func TestGetPayloadDetectorAuthorizedWriters(t *testing.T) {
+	payload := getTestPayloadDetector(t, map[string]interface{}{
+		"teams":                   []interface{}{"teamId"},
+		"authorized_writer_teams": []interface{}{"writerTeamId"},
+		"authorized_writer_users": []interface{}{"userId"},
+	})
+	assert.Equal(t, []interface{}{"teamId"}, payload["teams"])
+	assert.Equal(t, map[string]interface{}{
+		"teams": []interface{}{"writerTeamId"},
+		"users": []interface{}{"userId"},
+	}, payload["authorizedWriters"])
+
+	// Without writers anyone can edit the detector
+	payload = getTestPayloadDetector(t, map[string]interface{}{})
+	assert.NotContains(t, payload, "teams")
+	assert.NotContains(t, payload, "authorizedWriters")
+}
+
+func TestGetPayloadDetectorVisualizationOptions(t *testing.T) {
+	payload := getTestPayloadDetector(t, map[string]interface{}{
+		"show_data_markers": true,
+		"disable_sampling":  true,
+		"axis_right": []interface{}{
+			map[string]interface{}{"label": "Usage", "min_value": 0},
+		},
+		"viz_options": []interface{}{
+			map[string]interface{}{
+				"label":      "CPU",
+				"color":      "blue",
+				"axis":       "right",
+				"plot_type":  "AreaChart",
+				"value_unit": "Byte",
+			},
+		},
+	})
+
+	viz := payload["visualizationOptions"].(map[string]interface{})
+	assert.Equal(t, true, viz["showDataMarkers"])
+	assert.Equal(t, true, viz["disableSampling"])
+	axes := viz["axes"].([]interface{})
+	assert.Nil(t, axes[0])
+	assert.Equal(t, "Usage", axes[1].(map[string]interface{})["label"])
+	assert.Equal(t, 0.0, axes[1].(map[string]interface{})["min"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"label":        "CPU",
+			"paletteIndex": float64(getPlotPaletteIndex("blue")),
+			"yAxis":        1.0,
+			"plotType":     "AreaChart",
+			"valueUnit":    "Byte",
+		},
+	}, viz["publishLabelOptions"])
+}
+
+func TestReadTeamPolicyHashDetector(t *testing.T) {
+	policy := `{"notificationLists": {"critical": [{"type": "PagerDuty", "credentialId": "credId"}]}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, policy)
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, detectorResource().Schema, map[string]interface{}{
+		"teams": []interface{}{"teamA"},
+		"rule": []interface{}{
+			map[string]interface{}{
+				"detect_label":       "high",
+				"severity":           "Critical",
+				"notify_team_policy": true,
+			},
+		},
+	})
+	assert.Nil(t, readTeamPolicyHashDetector(d, server.URL, "token"))
+	applied := d.Get("team_policy_hash").(string)
+	assert.NotEqual(t, "", applied)
+	assert.Equal(t, true, d.Get("synced"))
+
+	// A policy change of another severity doesn't matter
+	policy = `{"notificationLists": {"critical": [{"type": "PagerDuty", "credentialId": "credId"}], "minor": [{"type": "Email", "email": "a@yelp.com"}]}}`
+	assert.Nil(t, readTeamPolicyHashDetector(d, server.URL, "token"))
+	assert.Equal(t, applied, d.Get("team_policy_hash"))
+	assert.Equal(t, true, d.Get("synced"))
+
+	policy = `{"notificationLists": {"critical": [{"type": "Email", "email": "a@yelp.com"}]}}`
+	assert.Nil(t, readTeamPolicyHashDetector(d, server.URL, "token"))
+	assert.Equal(t, applied, d.Get("team_policy_hash"))
+	assert.Equal(t, false, d.Get("synced"))
+}
@@ -0,0 +1,160 @@
+package signalform
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func eventFeedChartResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"synced": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the resource in SignalForm and SignalFx are identical or not. Used internally for syncing.",
+			},
+			"last_updated": &schema.Schema{
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Latest timestamp the resource was updated",
+			},
+			"resource_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     CHART_URL,
+				Description: "API URL of the chart",
+			},
+			"url": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the chart",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the chart",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the chart",
+			},
+			"program_text": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Signalflow program text for the chart, selecting the events to display (e.g. events(eventType='deploy').publish()). More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
+			},
+			"time_range": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateSignalfxRelativeTime,
+				Description:   "From when to display events. SignalFx time syntax (e.g. -5m, -1h, -1h30m)",
+				ConflictsWith: []string{"start_time", "end_time"},
+			},
+			"time_range_end": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateSignalfxRelativeTime,
+				Description:   "(Now by default) Until when to display events. Requires time_range and must be after it. SignalFx time syntax (e.g. -1h)",
+				ConflictsWith: []string{"start_time", "end_time"},
+			},
+			"start_time": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateAbsoluteTime,
+				Description:   "Seconds since epoch or RFC3339 timestamp (e.g. 2018-01-01T00:00:00Z) to start the visualization",
+				ConflictsWith: []string{"time_range", "time_range_end"},
+			},
+			"end_time": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateAbsoluteTime,
+				Description:   "Seconds since epoch or RFC3339 timestamp (e.g. 2018-01-01T00:00:00Z) to end the visualization",
+				ConflictsWith: []string{"time_range", "time_range_end"},
+			},
+			"tags": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags associated with the chart",
+			},
+		},
+
+		Create: eventfeedchartCreate,
+		Read:   eventfeedchartRead,
+		Update: eventfeedchartUpdate,
+		Delete: eventfeedchartDelete,
+	}
+}
+
+/*
+  Use Resource object to construct json payload in order to create an event feed chart
+*/
+func getPayloadEventFeedChart(d *schema.ResourceData, config *signalformConfig) ([]byte, error) {
+	if err := validateTimeRange(d); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"programText": sanitizeProgramText(d.Get("program_text").(string)),
+	}
+
+	viz := getEventFeedChartOptions(d)
+	if len(viz) > 0 {
+		payload["options"] = viz
+	}
+
+	if tags := getTags(d, config.DefaultTags); len(tags) > 0 {
+		payload["tags"] = tags
+	}
+
+	return json.Marshal(payload)
+}
+
+func getEventFeedChartOptions(d *schema.ResourceData) map[string]interface{} {
+	viz := make(map[string]interface{})
+	viz["type"] = "Event"
+	if timeMap := getTimeOptions(d); len(timeMap) > 0 {
+		viz["time"] = timeMap
+	}
+
+	return viz
+}
+
+func eventfeedchartCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	payload, err := getPayloadEventFeedChart(d, config)
+	if err != nil {
+		return fmt.Errorf("Failed creating json payload: %s", err.Error())
+	}
+
+	return resourceCreate(CHART_API_URL, config.AuthToken, payload, d)
+}
+
+func eventfeedchartRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	url := fmt.Sprintf("%s/%s", CHART_API_URL, d.Id())
+
+	return resourceReadTags(url, config, d)
+}
+
+func eventfeedchartUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	payload, err := getPayloadEventFeedChart(d, config)
+	if err != nil {
+		return fmt.Errorf("Failed creating json payload: %s", err.Error())
+	}
+	url := fmt.Sprintf("%s/%s", CHART_API_URL, d.Id())
+
+	return resourceUpdate(url, config.AuthToken, payload, d)
+}
+
+func eventfeedchartDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	url := fmt.Sprintf("%s/%s", CHART_API_URL, d.Id())
+	return resourceDelete(url, config.AuthToken, d)
+}
@@ -0,0 +1,34 @@
+package signalform
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetPayloadEventFeedChart(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, eventFeedChartResource().Schema, map[string]interface{}{
+		"name":         "Deploys",
+		"description":  "Deploys of the app",
+		"program_text": "A = events(eventType='deploy').publish(label='A')",
+		"time_range":   "-1h",
+		"tags":         []interface{}{"team:app"},
+	})
+	payload, err := getPayloadEventFeedChart(d, &signalformConfig{DefaultTags: []string{"owner:signalform"}})
+	assert.Nil(t, err)
+
+	mapped_payload := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(payload, &mapped_payload))
+	expected := map[string]interface{}{
+		"name":        "Deploys",
+		"description": "Deploys of the app",
+		"programText": "A = events(eventType='deploy').publish(label='A')",
+		"options": map[string]interface{}{
+			"type": "Event",
+			"time": map[string]interface{}{"range": 3600000.0, "type": "relative"},
+		},
+		"tags": []interface{}{"team:app", "owner:signalform"},
+	}
+	assert.Equal(t, expected, mapped_payload)
+}
@@ -104,8 +104,8 @@ func heatmapChartResource() *schema.Resource {
 						"color": &schema.Schema{
 							Type:         schema.TypeString,
 							Required:     true,
-							Description:  "The color range to use. Must be either \"gray\", \"blue\", \"navy\", \"orange\", \"yellow\", \"magenta\", \"purple\", \"violet\", \"lilac\", \"green\", \"aquamarine\"",
-							ValidateFunc: validateHeatmapChartColor,
+							Description:  "The color range to use. Palette name or hex code (e.g. #ff0000)",
+							ValidateFunc: validateChartColor,
 						},
 					},
 				},
@@ -117,34 +117,38 @@ func heatmapChartResource() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"gt": &schema.Schema{
-							Type:        schema.TypeFloat,
-							Optional:    true,
-							Default:     math.MaxFloat32,
-							Description: "Indicates the lower threshold non-inclusive value for this range",
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      math.MaxFloat32,
+							ValidateFunc: validateColorScaleBound,
+							Description:  "Indicates the lower threshold non-inclusive value for this range",
 						},
 						"gte": &schema.Schema{
-							Type:        schema.TypeFloat,
-							Optional:    true,
-							Default:     math.MaxFloat32,
-							Description: "Indicates the lower threshold inclusive value for this range",
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      math.MaxFloat32,
+							ValidateFunc: validateColorScaleBound,
+							Description:  "Indicates the lower threshold inclusive value for this range",
 						},
 						"lt": &schema.Schema{
-							Type:        schema.TypeFloat,
-							Optional:    true,
-							Default:     math.MaxFloat32,
-							Description: "Indicates the upper threshold non-inculsive value for this range",
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      math.MaxFloat32,
+							ValidateFunc: validateColorScaleBound,
+							Description:  "Indicates the upper threshold non-inculsive value for this range",
 						},
 						"lte": &schema.Schema{
-							Type:        schema.TypeFloat,
-							Optional:    true,
-							Default:     math.MaxFloat32,
-							Description: "Indicates the upper threshold inclusive value for this range",
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      math.MaxFloat32,
+							ValidateFunc: validateColorScaleBound,
+							Description:  "Indicates the upper threshold inclusive value for this range",
 						},
 						"color": &schema.Schema{
 							Type:         schema.TypeString,
 							Required:     true,
-							Description:  "The color to use. Must be either \"gray\", \"blue\", \"navy\", \"orange\", \"yellow\", \"magenta\", \"purple\", \"violet\", \"lilac\", \"green\", \"aquamarine\"",
-							ValidateFunc: validateHeatmapChartColor,
+							Description:  "The color to use. Palette name or hex code (e.g. #ff0000)",
+							ValidateFunc: validateChartColor,
 						},
 					},
 				},
@@ -155,6 +159,28 @@ func heatmapChartResource() *schema.Resource {
 				Default:     false,
 				Description: "(false by default) Whether to show the timestamp in the chart",
 			},
+			"refresh_interval": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "How often (in seconds) to refresh the values of the heatmap",
+			},
+			"max_precision": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum number of digits to display when rounding values up or down",
+			},
+			"legend_fields_to_hide": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "List of properties that shouldn't be displayed in the chart legend (i.e. dimension names)",
+			},
+			"tags": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags associated with the chart",
+			},
 		},
 
 		Create: heatmapchartCreate,
@@ -167,7 +193,11 @@ func heatmapChartResource() *schema.Resource {
 /*
   Use Resource object to construct json payload in order to create an Heatmap chart
 */
-func getPayloadHeatmapChart(d *schema.ResourceData) ([]byte, error) {
+func getPayloadHeatmapChart(d *schema.ResourceData, config *signalformConfig) ([]byte, error) {
+	if err := validateColorScale(d.Get("color_scale").(*schema.Set).List()); err != nil {
+		return nil, err
+	}
+
 	payload := map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
@@ -178,6 +208,9 @@ func getPayloadHeatmapChart(d *schema.ResourceData) ([]byte, error) {
 	if len(viz) > 0 {
 		payload["options"] = viz
 	}
+	if tags := getTags(d, config.DefaultTags); len(tags) > 0 {
+		payload["tags"] = tags
+	}
 
 	return json.Marshal(payload)
 }
@@ -198,12 +231,8 @@ func getHeatmapColorRangeOptions(d *schema.ResourceData) map[string]interface{}
 				item["max"] = val.(float64)
 			}
 		}
-		color := options["color"].(string)
-		for _, colorStruct := range ChartColorsSlice {
-			if color == colorStruct.name {
-				item["color"] = colorStruct.name
-				break
-			}
+		if hex, ok := getColorHex(options["color"].(string)); ok {
+			item["color"] = hex
 		}
 	}
 	return item
@@ -249,13 +278,22 @@ func getHeatmapOptionsChart(d *schema.ResourceData) map[string]interface{} {
 	}
 
 	viz["timestampHidden"] = d.Get("hide_timestamp").(bool)
+	if refreshInterval, ok := d.GetOk("refresh_interval"); ok {
+		viz["refreshInterval"] = refreshInterval.(int) * 1000
+	}
+	if maxPrecision, ok := d.GetOk("max_precision"); ok {
+		viz["maximumPrecision"] = maxPrecision.(int)
+	}
+	if legendOptions := getLegendOptions(d); len(legendOptions) > 0 {
+		viz["legendOptions"] = legendOptions
+	}
 
 	return viz
 }
 
 func heatmapchartCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadHeatmapChart(d)
+	payload, err := getPayloadHeatmapChart(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
@@ -267,12 +305,12 @@ func heatmapchartRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
 	url := fmt.Sprintf("%s/%s", CHART_API_URL, d.Id())
 
-	return resourceRead(url, config.AuthToken, d)
+	return resourceReadTags(url, config, d)
 }
 
 func heatmapchartUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadHeatmapChart(d)
+	payload, err := getPayloadHeatmapChart(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
@@ -286,23 +324,3 @@ func heatmapchartDelete(d *schema.ResourceData, meta interface{}) error {
 	url := fmt.Sprintf("%s/%s", CHART_API_URL, d.Id())
 	return resourceDelete(url, config.AuthToken, d)
 }
-
-/*
-  Validates the color_range field against a list of allowed words.
-*/
-func validateHeatmapChartColor(v interface{}, k string) (we []string, errors []error) {
-	value := v.(string)
-	keys := make([]string, 0, len(ChartColorsSlice))
-	found := false
-	for _, item := range ChartColorsSlice {
-		if value == item.name {
-			found = true
-		}
-		keys = append(keys, item.name)
-	}
-	if !found {
-		joinedColors := strings.Join(keys, ",")
-		errors = append(errors, fmt.Errorf("%s not allowed; must be either %s", value, joinedColors))
-	}
-	return
-}
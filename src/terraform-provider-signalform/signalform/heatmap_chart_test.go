@@ -6,11 +6,11 @@ import (
 )
 
 func TestValidateHeatmapChartColors(t *testing.T) {
-	_, err := validateHeatmapChartColor("blue", "color")
+	_, err := validateChartColor("blue", "color")
 	assert.Equal(t, 0, len(err))
 }
 
 func TestValidateHeatmapChartColorsFail(t *testing.T) {
-	_, err := validateHeatmapChartColor("whatever", "color")
+	_, err := validateChartColor("whatever", "color")
 	assert.Equal(t, 1, len(err))
 }
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
+	"math"
+	"strings"
 )
 
 func listChartResource() *schema.Resource {
@@ -52,9 +54,10 @@ func listChartResource() *schema.Resource {
 				Description: "(Metric by default) Must be \"Metric\" or \"Binary\"",
 			},
 			"color_by": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "(Metric by default) Must be \"Metric\" or \"Dimension\"",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateListChartColorBy,
+				Description:  "(Metric by default) Must be \"Metric\", \"Dimension\", or \"Scale\". \"Scale\" maps to Color by Value in the UI",
 			},
 			"max_delay": &schema.Schema{
 				Type:         schema.TypeInt,
@@ -79,16 +82,93 @@ func listChartResource() *schema.Resource {
 				Description: "How often (in seconds) to refresh the values of the list",
 			},
 			"legend_fields_to_hide": &schema.Schema{
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Description: "List of properties that shouldn't be displayed in the chart legend (i.e. dimension names)",
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"legend_options_fields"},
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Description:   "List of properties that shouldn't be displayed in the chart legend (i.e. dimension names)",
+			},
+			"legend_options_fields": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"legend_fields_to_hide"},
+				Description:   "List of properties of the chart legend, in the order they should be displayed",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the property (i.e. dimension name). Use 'metric' for the metric name and 'plot_label' for the plot label",
+						},
+						"enabled": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "(true by default) Whether the property should be displayed in the chart legend",
+						},
+					},
+				},
 			},
 			"max_precision": &schema.Schema{
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Description: "Maximum number of digits to display when rounding values up or down",
 			},
+			"tags": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags associated with the chart",
+			},
+			"secondary_visualization": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateSecondaryVisualization,
+				Description:  "(None by default) The type of secondary visualization. Must be \"None\", \"Radial\", \"Linear\", or \"Sparkline\"",
+			},
+			"color_scale": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Single color range including both the color to display for that range and the borders of the range",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"gt": &schema.Schema{
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      math.MaxFloat32,
+							ValidateFunc: validateColorScaleBound,
+							Description:  "Indicates the lower threshold non-inclusive value for this range",
+						},
+						"gte": &schema.Schema{
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      math.MaxFloat32,
+							ValidateFunc: validateColorScaleBound,
+							Description:  "Indicates the lower threshold inclusive value for this range",
+						},
+						"lt": &schema.Schema{
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      math.MaxFloat32,
+							ValidateFunc: validateColorScaleBound,
+							Description:  "Indicates the upper threshold non-inculsive value for this range",
+						},
+						"lte": &schema.Schema{
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      math.MaxFloat32,
+							ValidateFunc: validateColorScaleBound,
+							Description:  "Indicates the upper threshold inclusive value for this range",
+						},
+						"color": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The color to use. Palette name or hex code (e.g. #ff0000)",
+							ValidateFunc: validateChartColor,
+						},
+					},
+				},
+			},
 			"viz_options": &schema.Schema{
 				Type:        schema.TypeSet,
 				Optional:    true,
@@ -100,17 +180,34 @@ func listChartResource() *schema.Resource {
 							Required:    true,
 							Description: "The label used in the publish statement that displays the plot (metric time series data) you want to customize",
 						},
+						"display_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name to display for this plot instead of its label",
+						},
+						"hidden": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "(false by default) Whether this plot should be published but not drawn (e.g. for helper streams)",
+						},
 						"color": &schema.Schema{
 							Type:         schema.TypeString,
 							Optional:     true,
 							Description:  "Color to use",
-							ValidateFunc: validatePerSignalColor,
+							ValidateFunc: validateChartColor,
 						},
 						"value_unit": &schema.Schema{
 							Type:         schema.TypeString,
 							Optional:     true,
 							ValidateFunc: validateUnitTimeChart,
-							Description:  "A unit to attach to this plot. Units support automatic scaling (eg thousands of bytes will be displayed as kilobytes)",
+							Description:  "A unit to attach to this plot. Units support automatic scaling (eg thousands of bytes will be displayed as kilobytes, 90 seconds as 1.5m)",
+						},
+						"value_unit_prefix": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateValueUnitPrefix,
+							Description:  "(Metric by default) Prefix of the scaled values of value_unit. Must be \"Metric\" or \"Binary\"",
 						},
 						"value_prefix": &schema.Schema{
 							Type:        schema.TypeString,
@@ -137,14 +234,21 @@ func listChartResource() *schema.Resource {
 /*
   Use Resource object to construct json payload in order to create a list chart
 */
-func getPayloadListChart(d *schema.ResourceData) ([]byte, error) {
+func getPayloadListChart(d *schema.ResourceData, config *signalformConfig) ([]byte, error) {
+	if err := validateColorScale(d.Get("color_scale").(*schema.Set).List()); err != nil {
+		return nil, err
+	}
+
 	payload := map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
 		"programText": sanitizeProgramText(d.Get("program_text").(string)),
 	}
 
-	viz := getListChartOptions(d)
+	viz, err := getListChartOptions(d)
+	if err != nil {
+		return nil, err
+	}
 	if legendOptions := getLegendOptions(d); len(legendOptions) > 0 {
 		viz["legendOptions"] = legendOptions
 	}
@@ -155,17 +259,33 @@ func getPayloadListChart(d *schema.ResourceData) ([]byte, error) {
 		payload["options"] = viz
 	}
 
+	if tags := getTags(d, config.DefaultTags); len(tags) > 0 {
+		payload["tags"] = tags
+	}
+
 	return json.Marshal(payload)
 }
 
-func getListChartOptions(d *schema.ResourceData) map[string]interface{} {
+func getListChartOptions(d *schema.ResourceData) (map[string]interface{}, error) {
 	viz := make(map[string]interface{})
 	viz["type"] = "List"
 	if val, ok := d.GetOk("unit_prefix"); ok {
 		viz["unitPrefix"] = val.(string)
 	}
 	if val, ok := d.GetOk("color_by"); ok {
-		viz["colorBy"] = val.(string)
+		if val == "Scale" {
+			colorScaleOptions := getColorScaleOptions(d)
+			if len(colorScaleOptions) == 0 {
+				return nil, fmt.Errorf("color_by is \"Scale\" but no color_scale is set")
+			}
+			viz["colorBy"] = "Scale"
+			viz["colorScale"] = colorScaleOptions
+		} else {
+			viz["colorBy"] = val.(string)
+		}
+	}
+	if val, ok := d.GetOk("secondary_visualization"); ok {
+		viz["secondaryVisualization"] = val.(string)
 	}
 
 	programOptions := make(map[string]interface{})
@@ -185,12 +305,12 @@ func getListChartOptions(d *schema.ResourceData) map[string]interface{} {
 		viz["maximumPrecision"] = maxPrecision.(int)
 	}
 
-	return viz
+	return viz, nil
 }
 
 func listchartCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadListChart(d)
+	payload, err := getPayloadListChart(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
@@ -202,12 +322,12 @@ func listchartRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
 	url := fmt.Sprintf("%s/%s", CHART_API_URL, d.Id())
 
-	return resourceRead(url, config.AuthToken, d)
+	return resourceReadTags(url, config, d)
 }
 
 func listchartUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadListChart(d)
+	payload, err := getPayloadListChart(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
@@ -222,3 +342,18 @@ func listchartDelete(d *schema.ResourceData, meta interface{}) error {
 
 	return resourceDelete(url, config.AuthToken, d)
 }
+
+/*
+  Validates the color_by field against a list of allowed words.
+*/
+func validateListChartColorBy(v interface{}, k string) (we []string, errors []error) {
+	value := v.(string)
+	allowedWords := []string{"Metric", "Dimension", "Scale"}
+	for _, word := range allowedWords {
+		if value == word {
+			return
+		}
+	}
+	errors = append(errors, fmt.Errorf("%s not allowed; must be one of: %s", value, strings.Join(allowedWords, ", ")))
+	return
+}
@@ -0,0 +1,56 @@
+package signalform
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateListChartColorByAllowed(t *testing.T) {
+	for _, value := range []string{"Metric", "Dimension", "Scale"} {
+		_, errors := validateListChartColorBy(value, "color_by")
+		assert.Equal(t, 0, len(errors))
+	}
+}
+
+func TestValidateListChartColorByNotAllowed(t *testing.T) {
+	_, errors := validateListChartColorBy("Value", "color_by")
+	assert.Equal(t, 1, len(errors))
+}
+
+func TestGetPayloadListChartColorScale(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, listChartResource().Schema, map[string]interface{}{
+		"name":                    "CPU",
+		"program_text":            "data('cpu.utilization').publish(label='CPU')",
+		"color_by":                "Scale",
+		"secondary_visualization": "Sparkline",
+		"color_scale": []interface{}{
+			map[string]interface{}{"gt": 60.0, "color": "red"},
+			map[string]interface{}{"lte": 60.0, "color": "green"},
+		},
+	})
+	payload, err := getPayloadListChart(d, &signalformConfig{})
+	assert.Nil(t, err)
+
+	mapped_payload := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(payload, &mapped_payload))
+	options := mapped_payload["options"].(map[string]interface{})
+	assert.Equal(t, "Scale", options["colorBy"])
+	assert.Equal(t, "Sparkline", options["secondaryVisualization"])
+	assert.ElementsMatch(t, []interface{}{
+		map[string]interface{}{"gt": 60.0, "paletteIndex": float64(getScalePaletteIndex("red"))},
+		map[string]interface{}{"lte": 60.0, "paletteIndex": float64(getScalePaletteIndex("green"))},
+	}, options["colorScale"])
+}
+
+func TestGetPayloadListChartScaleWithoutColorScale(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, listChartResource().Schema, map[string]interface{}{
+		"name":         "CPU",
+		"program_text": "data('cpu.utilization').publish(label='CPU')",
+		"color_by":     "Scale",
+	})
+	_, err := getPayloadListChart(d, &signalformConfig{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no color_scale is set")
+}
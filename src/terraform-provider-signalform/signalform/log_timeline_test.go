@@ -0,0 +1,31 @@
+package signalform
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetPayloadLogTimeline(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, logTimelineResource().Schema, map[string]interface{}{
+		"name":               "Errors over time",
+		"program_text":       "logs(filter=field('severity') == 'ERROR').count().publish()",
+		"time_range":         "-1d",
+		"default_connection": "my-connection",
+		"tags":               []interface{}{"team:app"},
+	})
+	payload, err := getPayloadLogTimeline(d, &signalformConfig{})
+	assert.Nil(t, err)
+
+	mapped_payload := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(payload, &mapped_payload))
+	expected := map[string]interface{}{
+		"type":              "LogsTimeSeriesChart",
+		"time":              map[string]interface{}{"range": 86400000.0, "type": "relative"},
+		"defaultConnection": "my-connection",
+	}
+	assert.Equal(t, "Errors over time", mapped_payload["name"])
+	assert.Equal(t, expected, mapped_payload["options"])
+	assert.Equal(t, []interface{}{"team:app"}, mapped_payload["tags"])
+}
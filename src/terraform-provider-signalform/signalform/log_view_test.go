@@ -0,0 +1,42 @@
+package signalform
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetPayloadLogView(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, logViewResource().Schema, map[string]interface{}{
+		"name":               "Errors",
+		"program_text":       "logs(filter=field('severity') == 'ERROR').publish()",
+		"start_time":         "2018-01-01T00:00:00Z",
+		"end_time":           "2018-01-02T00:00:00Z",
+		"default_connection": "my-connection",
+		"columns":            []interface{}{"severity", "message"},
+		"sort_options": []interface{}{
+			map[string]interface{}{"field": "severity", "descending": true},
+		},
+	})
+	payload, err := getPayloadLogView(d, &signalformConfig{})
+	assert.Nil(t, err)
+
+	mapped_payload := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(payload, &mapped_payload))
+	expected := map[string]interface{}{
+		"type":              "LogsChart",
+		"time":              map[string]interface{}{"start": 1514764800000.0, "end": 1514851200000.0, "type": "absolute"},
+		"defaultConnection": "my-connection",
+		"columns": []interface{}{
+			map[string]interface{}{"name": "severity"},
+			map[string]interface{}{"name": "message"},
+		},
+		"sortOptions": []interface{}{
+			map[string]interface{}{"field": "severity", "descending": true},
+		},
+	}
+	assert.Equal(t, "Errors", mapped_payload["name"])
+	assert.Equal(t, "logs(filter=field('severity') == 'ERROR').publish()", mapped_payload["programText"])
+	assert.Equal(t, expected, mapped_payload["options"])
+}
@@ -0,0 +1,354 @@
+package signalform
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+/*
+  Variables available in custom notification messages. See https://developers.signalfx.com/v2/reference#section-custom-notification-messages
+  The ones listed in NotificationNestedVariables also accept any sub-property (e.g. {{dimensions.host}}).
+*/
+var NotificationVariables = []string{
+	"anomalous",
+	"anomalyState",
+	"detectorId",
+	"detectorName",
+	"detectorUrl",
+	"imageUrl",
+	"incidentId",
+	"normal",
+	"readableRule",
+	"ruleName",
+	"ruleSeverity",
+	"runbookUrl",
+	"timestamp",
+	"tip",
+}
+
+var NotificationNestedVariables = []string{"dimensions", "event_annotations", "inputs"}
+
+var NotificationBlockHelpers = []string{"each", "if", "notEmpty", "unless"}
+
+var notificationTagRegexp = regexp.MustCompile(`\{\{\{?~?\s*(.*?)\s*~?\}?\}\}`)
+
+type notificationNode struct {
+	text     string
+	variable string
+	block    string
+	argument string
+	children []*notificationNode
+	inverse  []*notificationNode
+}
+
+func notificationPreviewDataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"template": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateNotificationTemplate,
+				Description:  "The parameterized_subject or parameterized_body to render",
+			},
+			"detector_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Sample detector",
+				Description: "Value of {{detectorName}}",
+			},
+			"rule_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Sample rule",
+				Description: "Value of {{ruleName}}",
+			},
+			"rule_severity": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Critical",
+				ValidateFunc: validateSeverity,
+				Description:  "Value of {{ruleSeverity}}",
+			},
+			"anomalous": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "(true by default) Whether to render the notification sent when the alert fires (true) or clears (false)",
+			},
+			"runbook_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Value of {{runbookUrl}}",
+			},
+			"tip": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Value of {{tip}}",
+			},
+			"dimensions": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Values of {{dimensions.*}}",
+			},
+			"inputs": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Values of the program inputs, by name (i.e. {{inputs.<name>.value}})",
+			},
+			"rendered": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The rendered notification",
+			},
+		},
+
+		Read: notificationPreviewRead,
+	}
+}
+
+func notificationPreviewRead(d *schema.ResourceData, meta interface{}) error {
+	anomalous := d.Get("anomalous").(bool)
+	anomalyState := "ANOMALOUS"
+	if !anomalous {
+		anomalyState = "OK"
+	}
+	inputs := make(map[string]interface{})
+	for name, value := range d.Get("inputs").(map[string]interface{}) {
+		inputs[name] = map[string]interface{}{"value": value}
+	}
+	context := map[string]interface{}{
+		"anomalous":    anomalous,
+		"normal":       !anomalous,
+		"anomalyState": anomalyState,
+		"detectorName": d.Get("detector_name").(string),
+		"ruleName":     d.Get("rule_name").(string),
+		"ruleSeverity": d.Get("rule_severity").(string),
+		"runbookUrl":   d.Get("runbook_url").(string),
+		"tip":          d.Get("tip").(string),
+		"dimensions":   d.Get("dimensions").(map[string]interface{}),
+		"inputs":       inputs,
+	}
+
+	rendered, err := renderNotificationTemplate(d.Get("template").(string), context)
+	if err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%d", hashcode.String(rendered)))
+	d.Set("rendered", rendered)
+	return nil
+}
+
+/*
+  Validates the handlebars syntax of a custom notification message, and that it only uses known variables
+*/
+func validateNotificationTemplate(v interface{}, k string) (we []string, errors []error) {
+	if _, err := parseNotificationTemplate(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%s: %s", k, err.Error()))
+	}
+	return
+}
+
+func renderNotificationTemplate(template string, context map[string]interface{}) (string, error) {
+	nodes, err := parseNotificationTemplate(template)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	renderNotificationNodes(&buf, nodes, context)
+	return buf.String(), nil
+}
+
+/*
+  Parse the template into a tree of text, variables and blocks ({{#if ...}} ... {{else}} ... {{/if}})
+*/
+func parseNotificationTemplate(template string) ([]*notificationNode, error) {
+	root := &notificationNode{}
+	stack := []*notificationNode{root}
+	inElse := []bool{false}
+	eachDepth := 0
+
+	add := func(node *notificationNode) {
+		parent := stack[len(stack)-1]
+		if inElse[len(inElse)-1] {
+			parent.inverse = append(parent.inverse, node)
+		} else {
+			parent.children = append(parent.children, node)
+		}
+	}
+
+	position := 0
+	for _, match := range notificationTagRegexp.FindAllStringSubmatchIndex(template, -1) {
+		text := template[position:match[0]]
+		if strings.Contains(text, "{{") || strings.Contains(text, "}}") {
+			return nil, fmt.Errorf("unbalanced braces in %q", text)
+		}
+		if text != "" {
+			add(&notificationNode{text: text})
+		}
+		position = match[1]
+
+		tag := template[match[2]:match[3]]
+		switch {
+		case tag == "":
+			return nil, fmt.Errorf("empty tag {{}}")
+		case strings.HasPrefix(tag, "!"):
+			// comment
+		case strings.HasPrefix(tag, "#"):
+			fields := strings.Fields(tag[1:])
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("missing block helper in {{%s}}", tag)
+			}
+			if !stringInSlice(fields[0], NotificationBlockHelpers) {
+				return nil, fmt.Errorf("unknown block helper #%s; must be one of: %s", fields[0], strings.Join(NotificationBlockHelpers, ", "))
+			}
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("block helper #%s takes exactly one argument", fields[0])
+			}
+			if eachDepth == 0 {
+				if err := validateNotificationVariable(fields[1]); err != nil {
+					return nil, err
+				}
+			}
+			node := &notificationNode{block: fields[0], argument: fields[1]}
+			add(node)
+			stack = append(stack, node)
+			inElse = append(inElse, false)
+			if node.block == "each" {
+				eachDepth++
+			}
+		case strings.HasPrefix(tag, "/"):
+			name := strings.TrimSpace(tag[1:])
+			current := stack[len(stack)-1]
+			if len(stack) == 1 {
+				return nil, fmt.Errorf("{{/%s}} closes a block that was never opened", name)
+			}
+			if current.block != name {
+				return nil, fmt.Errorf("{{/%s}} doesn't match {{#%s}}", name, current.block)
+			}
+			if current.block == "each" {
+				eachDepth--
+			}
+			stack = stack[:len(stack)-1]
+			inElse = inElse[:len(inElse)-1]
+		case tag == "else":
+			if len(stack) == 1 || inElse[len(inElse)-1] {
+				return nil, fmt.Errorf("unexpected {{else}}")
+			}
+			inElse[len(inElse)-1] = true
+		default:
+			if eachDepth == 0 {
+				if err := validateNotificationVariable(tag); err != nil {
+					return nil, err
+				}
+			}
+			add(&notificationNode{variable: tag})
+		}
+	}
+
+	text := template[position:]
+	if strings.Contains(text, "{{") || strings.Contains(text, "}}") {
+		return nil, fmt.Errorf("unbalanced braces in %q", text)
+	}
+	if text != "" {
+		add(&notificationNode{text: text})
+	}
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("{{#%s}} is never closed", stack[len(stack)-1].block)
+	}
+	return root.children, nil
+}
+
+func validateNotificationVariable(variable string) error {
+	path := strings.SplitN(variable, ".", 2)
+	if stringInSlice(path[0], NotificationVariables) && len(path) == 1 {
+		return nil
+	}
+	if stringInSlice(path[0], NotificationNestedVariables) {
+		return nil
+	}
+	known := append(append([]string{}, NotificationVariables...), NotificationNestedVariables...)
+	sort.Strings(known)
+	return fmt.Errorf("unknown variable {{%s}}; must be one of: %s", variable, strings.Join(known, ", "))
+}
+
+func renderNotificationNodes(buf *bytes.Buffer, nodes []*notificationNode, context map[string]interface{}) {
+	for _, node := range nodes {
+		switch {
+		case node.block != "":
+			value := lookupNotificationVariable(node.argument, context)
+			switch node.block {
+			case "each":
+				values, _ := value.(map[string]interface{})
+				keys := make([]string, 0, len(values))
+				for key := range values {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+				for _, key := range keys {
+					scope := make(map[string]interface{})
+					for k, v := range context {
+						scope[k] = v
+					}
+					scope["this"] = values[key]
+					scope["@key"] = key
+					renderNotificationNodes(buf, node.children, scope)
+				}
+				if len(keys) == 0 {
+					renderNotificationNodes(buf, node.inverse, context)
+				}
+			default:
+				truthy := isNotificationValueTruthy(value)
+				if node.block == "unless" {
+					truthy = !truthy
+				}
+				if truthy {
+					renderNotificationNodes(buf, node.children, context)
+				} else {
+					renderNotificationNodes(buf, node.inverse, context)
+				}
+			}
+		case node.variable != "":
+			if value := lookupNotificationVariable(node.variable, context); value != nil {
+				buf.WriteString(fmt.Sprintf("%v", value))
+			}
+		default:
+			buf.WriteString(node.text)
+		}
+	}
+}
+
+func lookupNotificationVariable(variable string, context map[string]interface{}) interface{} {
+	var value interface{} = context
+	for _, name := range strings.Split(variable, ".") {
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if value, ok = values[name]; !ok {
+			return nil
+		}
+	}
+	return value
+}
+
+func isNotificationValueTruthy(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	case string:
+		return value != ""
+	case map[string]interface{}:
+		return len(value) > 0
+	}
+	return true
+}
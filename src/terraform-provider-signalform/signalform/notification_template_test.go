@@ -0,0 +1,59 @@
+package signalform
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateNotificationTemplateAllowed(t *testing.T) {
+	templates := []string{
+		"No variables at all",
+		"{{ruleName}} on {{dimensions.host}} ({{inputs.A.value}})",
+		"{{#if anomalous}}Rule {{{ruleName}}} triggered{{else}}Rule {{ruleName}} cleared{{/if}}",
+		"{{#notEmpty dimensions}}{{#each dimensions}}{{@key}}={{this}} {{/each}}{{/notEmpty}}",
+		"{{! a comment }}{{event_annotations.team}}",
+	}
+	for _, template := range templates {
+		_, errors := validateNotificationTemplate(template, "parameterized_body")
+		assert.Equal(t, 0, len(errors), template)
+	}
+}
+
+func TestValidateNotificationTemplateNotAllowed(t *testing.T) {
+	templates := []string{
+		"{{ruleName}",
+		"ruleName}}",
+		"{{}}",
+		"{{rulename}}",
+		"{{ruleName.foo}}",
+		"{{#if anomalous}}unclosed",
+		"{{#if anomalous}}{{/unless}}",
+		"{{/if}}",
+		"{{else}}",
+		"{{#foreach dimensions}}{{/foreach}}",
+		"{{#if}}{{/if}}",
+	}
+	for _, template := range templates {
+		_, errors := validateNotificationTemplate(template, "parameterized_body")
+		assert.Equal(t, 1, len(errors), template)
+	}
+}
+
+func TestRenderNotificationTemplate(t *testing.T) {
+	context := map[string]interface{}{
+		"anomalous":  true,
+		"ruleName":   "High latency",
+		"dimensions": map[string]interface{}{"host": "foo", "cluster": "bar"},
+		"inputs":     map[string]interface{}{"A": map[string]interface{}{"value": "42"}},
+	}
+	template := "{{#if anomalous}}{{ruleName}} triggered{{else}}{{ruleName}} cleared{{/if}}: {{inputs.A.value}} [{{#each dimensions}}{{@key}}={{this}} {{/each}}]{{tip}}"
+
+	rendered, err := renderNotificationTemplate(template, context)
+	assert.Nil(t, err)
+	assert.Equal(t, "High latency triggered: 42 [cluster=bar host=foo ]", rendered)
+
+	context["anomalous"] = false
+	rendered, err = renderNotificationTemplate("{{#if anomalous}}triggered{{else}}cleared{{/if}}", context)
+	assert.Nil(t, err)
+	assert.Equal(t, "cleared", rendered)
+}
@@ -19,7 +19,8 @@ var HomeConfigSuffix = "/.signalfx.conf"
 var HomeConfigPath = ""
 
 type signalformConfig struct {
-	AuthToken string `json:"auth_token"`
+	AuthToken   string   `json:"auth_token"`
+	DefaultTags []string `json:"-"`
 }
 
 func Provider() terraform.ResourceProvider {
@@ -31,6 +32,12 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("SFX_AUTH_TOKEN", nil),
 				Description: "SignalFx auth token",
 			},
+			"default_tags": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags added to every chart, dashboard and detector. Tags of the resources take precedence",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"signalform_detector":           detectorResource(),
@@ -38,9 +45,21 @@ func Provider() terraform.ResourceProvider {
 			"signalform_heatmap_chart":      heatmapChartResource(),
 			"signalform_single_value_chart": singleValueChartResource(),
 			"signalform_list_chart":         listChartResource(),
+			"signalform_table_chart":        tableChartResource(),
 			"signalform_text_chart":         textChartResource(),
+			"signalform_event_feed_chart":   eventFeedChartResource(),
+			"signalform_log_view":           logViewResource(),
+			"signalform_log_timeline":       logTimelineResource(),
 			"signalform_dashboard":          dashboardResource(),
 			"signalform_dashboard_group":    dashboardGroupResource(),
+			"signalform_alert_muting_rule":  alertMutingRuleResource(),
+			"signalform_slo_detector":       sloDetectorResource(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"signalform_detector_preview":     detectorPreviewDataSource(),
+			"signalform_detector_events":      detectorEventsDataSource(),
+			"signalform_detector_template":    detectorTemplateDataSource(),
+			"signalform_notification_preview": notificationPreviewDataSource(),
 		},
 		ConfigureFunc: signalformConfigure,
 	}
@@ -103,6 +122,12 @@ func signalformConfigure(data *schema.ResourceData) (interface{}, error) {
 		log.Printf("[DEBUG] Did not find config in provider.\n")
 	}
 
+	if val, ok := data.GetOk("default_tags"); ok {
+		for _, tag := range val.([]interface{}) {
+			config.DefaultTags = append(config.DefaultTags, tag.(string))
+		}
+	}
+
 	if len(config.AuthToken) == 0 {
 		log.Printf("[DEBUG] config.AuthToken has length %d", len(config.AuthToken))
 		return &config, fmt.Errorf("auth_token: required field is not set")
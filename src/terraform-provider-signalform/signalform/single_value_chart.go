@@ -80,10 +80,24 @@ func singleValueChartResource() *schema.Resource {
 				Description: "(false by default) Whether to hide the timestamp in the chart",
 			},
 			"show_spark_line": &schema.Schema{
-				Type:        schema.TypeBool,
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"secondary_visualization"},
+				Description:   "(false by default) Whether to show a trend line below the current value",
+				Default:       false,
+			},
+			"secondary_visualization": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateSecondaryVisualization,
+				ConflictsWith: []string{"show_spark_line"},
+				Description:   "(None by default) The type of secondary visualization. Must be \"None\", \"Radial\", \"Linear\", or \"Sparkline\"",
+			},
+			"tags": &schema.Schema{
+				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "(false by default) Whether to show a trend line below the current value",
-				Default:     false,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags associated with the chart",
 			},
 			"color_scale": &schema.Schema{
 				Type:        schema.TypeSet,
@@ -92,34 +106,38 @@ func singleValueChartResource() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"gt": &schema.Schema{
-							Type:        schema.TypeFloat,
-							Optional:    true,
-							Default:     math.MaxFloat32,
-							Description: "Indicates the lower threshold non-inclusive value for this range",
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      math.MaxFloat32,
+							ValidateFunc: validateColorScaleBound,
+							Description:  "Indicates the lower threshold non-inclusive value for this range",
 						},
 						"gte": &schema.Schema{
-							Type:        schema.TypeFloat,
-							Optional:    true,
-							Default:     math.MaxFloat32,
-							Description: "Indicates the lower threshold inclusive value for this range",
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      math.MaxFloat32,
+							ValidateFunc: validateColorScaleBound,
+							Description:  "Indicates the lower threshold inclusive value for this range",
 						},
 						"lt": &schema.Schema{
-							Type:        schema.TypeFloat,
-							Optional:    true,
-							Default:     math.MaxFloat32,
-							Description: "Indicates the upper threshold non-inculsive value for this range",
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      math.MaxFloat32,
+							ValidateFunc: validateColorScaleBound,
+							Description:  "Indicates the upper threshold non-inculsive value for this range",
 						},
 						"lte": &schema.Schema{
-							Type:        schema.TypeFloat,
-							Optional:    true,
-							Default:     math.MaxFloat32,
-							Description: "Indicates the upper threshold inclusive value for this range",
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							Default:      math.MaxFloat32,
+							ValidateFunc: validateColorScaleBound,
+							Description:  "Indicates the upper threshold inclusive value for this range",
 						},
 						"color": &schema.Schema{
 							Type:         schema.TypeString,
 							Required:     true,
-							Description:  "The color to use. Must be either \"gray\", \"blue\", \"navy\", \"orange\", \"yellow\", \"magenta\", \"purple\", \"violet\", \"lilac\", \"green\", \"aquamarine\"",
-							ValidateFunc: validateHeatmapChartColor,
+							Description:  "The color to use. Palette name or hex code (e.g. #ff0000)",
+							ValidateFunc: validateChartColor,
 						},
 					},
 				},
@@ -135,17 +153,34 @@ func singleValueChartResource() *schema.Resource {
 							Required:    true,
 							Description: "The label used in the publish statement that displays the plot (metric time series data) you want to customize",
 						},
+						"display_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name to display for this plot instead of its label",
+						},
+						"hidden": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "(false by default) Whether this plot should be published but not drawn (e.g. for helper streams)",
+						},
 						"color": &schema.Schema{
 							Type:         schema.TypeString,
 							Optional:     true,
 							Description:  "Color to use",
-							ValidateFunc: validatePerSignalColor,
+							ValidateFunc: validateChartColor,
 						},
 						"value_unit": &schema.Schema{
 							Type:         schema.TypeString,
 							Optional:     true,
 							ValidateFunc: validateUnitTimeChart,
-							Description:  "A unit to attach to this plot. Units support automatic scaling (eg thousands of bytes will be displayed as kilobytes)",
+							Description:  "A unit to attach to this plot. Units support automatic scaling (eg thousands of bytes will be displayed as kilobytes, 90 seconds as 1.5m)",
+						},
+						"value_unit_prefix": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateValueUnitPrefix,
+							Description:  "(Metric by default) Prefix of the scaled values of value_unit. Must be \"Metric\" or \"Binary\"",
 						},
 						"value_prefix": &schema.Schema{
 							Type:        schema.TypeString,
@@ -172,7 +207,11 @@ func singleValueChartResource() *schema.Resource {
 /*
   Use Resource object to construct json payload in order to create a single value chart
 */
-func getPayloadSingleValueChart(d *schema.ResourceData) ([]byte, error) {
+func getPayloadSingleValueChart(d *schema.ResourceData, config *signalformConfig) ([]byte, error) {
+	if err := validateColorScale(d.Get("color_scale").(*schema.Set).List()); err != nil {
+		return nil, err
+	}
+
 	payload := map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
@@ -187,6 +226,10 @@ func getPayloadSingleValueChart(d *schema.ResourceData) ([]byte, error) {
 		payload["options"] = viz
 	}
 
+	if tags := getTags(d, config.DefaultTags); len(tags) > 0 {
+		payload["tags"] = tags
+	}
+
 	return json.Marshal(payload)
 }
 
@@ -220,14 +263,18 @@ func getSingleValueChartOptions(d *schema.ResourceData) map[string]interface{} {
 		viz["maximumPrecision"] = maxPrecision.(int)
 	}
 	viz["timestampHidden"] = d.Get("is_timestamp_hidden").(bool)
-	viz["showSparkLine"] = d.Get("show_spark_line").(bool)
+	if val, ok := d.GetOk("secondary_visualization"); ok {
+		viz["secondaryVisualization"] = val.(string)
+	} else {
+		viz["showSparkLine"] = d.Get("show_spark_line").(bool)
+	}
 
 	return viz
 }
 
 func singlevaluechartCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadSingleValueChart(d)
+	payload, err := getPayloadSingleValueChart(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
@@ -239,12 +286,12 @@ func singlevaluechartRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
 	url := fmt.Sprintf("%s/%s", CHART_API_URL, d.Id())
 
-	return resourceRead(url, config.AuthToken, d)
+	return resourceReadTags(url, config, d)
 }
 
 func singlevaluechartUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadSingleValueChart(d)
+	payload, err := getPayloadSingleValueChart(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
@@ -0,0 +1,327 @@
+package signalform
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const (
+	SLO_FAST_BURN_LABEL = "Fast burn rate"
+	SLO_SLOW_BURN_LABEL = "Slow burn rate"
+)
+
+/*
+  Multi-window burn rate alerts, as described in https://landing.google.com/sre/workbook/chapters/alerting-on-slos/
+  Each alert fires when both the long and the short window consume more than budgetSpent of the error budget
+  (scaled down to the long window).
+*/
+type sloBurnRateAlert struct {
+	label       string
+	severity    string
+	budgetSpent float64
+	longWindow  int // in minutes
+	shortWindow int // in minutes
+}
+
+var sloBurnRateAlerts = []sloBurnRateAlert{
+	{SLO_FAST_BURN_LABEL, "Critical", 0.02, 60, 5},
+	{SLO_SLOW_BURN_LABEL, "Major", 0.05, 6 * 60, 30},
+}
+
+func sloDetectorResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"synced": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the resource in SignalForm and SignalFx are identical or not. Used internally for syncing.",
+			},
+			"last_updated": &schema.Schema{
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Latest timestamp the resource was updated",
+			},
+			"url": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Url of the detector",
+			},
+			"resource_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     DETECTOR_URL,
+				Description: "Base Detector url",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the detector",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the detector",
+			},
+			"error_rate_metric": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"latency_series_metric"},
+				Description:   "Metric reporting the ratio (between 0 and 1) of failed requests. Conflicts with latency_series_metric",
+			},
+			"latency_series_metric": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"error_rate_metric"},
+				Description:   "Latency metric with one time series per host, endpoint, etc. (e.g. a p99). The error rate is the fraction of these time series above latency_threshold, not the fraction of requests. Conflicts with error_rate_metric",
+			},
+			"latency_threshold": &schema.Schema{
+				Type:          schema.TypeFloat,
+				Optional:      true,
+				ConflictsWith: []string{"error_rate_metric"},
+				Description:   "Latency above which a time series of latency_series_metric counts as failed. Required with latency_series_metric",
+			},
+			"filter": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SignalFlow filter to apply to the metric (e.g. filter('service', 'foo'))",
+			},
+			"target": &schema.Schema{
+				Type:         schema.TypeFloat,
+				Required:     true,
+				ValidateFunc: validateSloTarget,
+				Description:  "Percentage of the requests that must succeed over the window (e.g. 99.9)",
+			},
+			"window": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validateSloWindow,
+				Description:  "(30 by default) Length (in days) of the SLO window",
+			},
+			"max_delay": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "How long (in seconds) to wait for late datapoints. Max value 900s (15m)",
+				ValidateFunc: validateMaxDelayValue,
+			},
+			"critical_notifications": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Where to send the notifications of the fast burn rate (Critical) rule. Same format as the notifications of the detector rules",
+			},
+			"major_notifications": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Where to send the notifications of the slow burn rate (Major) rule. Same format as the notifications of the detector rules",
+			},
+			"tags": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags associated with the detector",
+			},
+			"teams": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Team IDs to associate the detector to",
+			},
+			"program_text": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The generated Signalflow program text, as read from SignalFx",
+			},
+		},
+
+		Create: slodetectorCreate,
+		Read:   slodetectorRead,
+		Update: slodetectorUpdate,
+		Delete: slodetectorDelete,
+	}
+}
+
+/*
+  Use Resource object to construct json payload in order to create an SLO detector
+*/
+func getPayloadSloDetector(d *schema.ResourceData, config *signalformConfig) ([]byte, error) {
+	var ratio string
+	filter := d.Get("filter").(string)
+	if val, ok := d.GetOk("error_rate_metric"); ok {
+		ratio = getSloErrorRateRatio(val.(string), filter)
+	} else if val, ok := d.GetOk("latency_series_metric"); ok {
+		threshold, ok := d.GetOkExists("latency_threshold")
+		if !ok {
+			return nil, fmt.Errorf("latency_threshold: required field is not set")
+		}
+		ratio = getSloLatencyRatio(val.(string), filter, threshold.(float64))
+	} else {
+		return nil, fmt.Errorf("One of error_rate_metric or latency_series_metric must be set")
+	}
+
+	programText := getSloProgramText(ratio, d.Get("target").(float64), d.Get("window").(int))
+
+	rules_list := make([]map[string]interface{}, len(sloBurnRateAlerts))
+	for i, alert := range sloBurnRateAlerts {
+		notifications := d.Get(fmt.Sprintf("%s_notifications", strings.ToLower(alert.severity))).([]interface{})
+		rules_list[i] = map[string]interface{}{
+			"description":   fmt.Sprintf("%.f%% of the error budget consumed in %s", alert.budgetSpent*100, formatSloWindow(alert.longWindow)),
+			"severity":      alert.severity,
+			"detectLabel":   alert.label,
+			"disabled":      false,
+			"notifications": getNotifications(notifications),
+		}
+	}
+
+	payload := map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"programText": programText,
+		"maxDelay":    nil,
+		"rules":       rules_list,
+	}
+
+	if val, ok := d.GetOk("max_delay"); ok {
+		payload["maxDelay"] = val.(int) * 1000
+	}
+
+	if val, ok := d.GetOk("teams"); ok {
+		teams := []string{}
+		for _, team := range val.([]interface{}) {
+			teams = append(teams, team.(string))
+		}
+		payload["teams"] = teams
+	}
+
+	if tags := getTags(d, config.DefaultTags); len(tags) > 0 {
+		payload["tags"] = tags
+	}
+
+	return json.Marshal(payload)
+}
+
+func getSloErrorRateRatio(metric string, filter string) string {
+	return fmt.Sprintf("data('%s'%s).mean()", metric, getSloFilterArgument(filter))
+}
+
+/*
+  The error rate is the ratio of time series above the latency threshold: each time series weighs the same,
+  whatever the number of requests behind it
+*/
+func getSloLatencyRatio(metric string, filter string, threshold float64) string {
+	stream := fmt.Sprintf("data('%s'%s)", metric, getSloFilterArgument(filter))
+	return fmt.Sprintf("(%s.above(%s, inclusive=False).count() / %s.count()).fill(0)", stream, strconv.FormatFloat(threshold, 'f', -1, 64), stream)
+}
+
+func getSloFilterArgument(filter string) string {
+	if filter == "" {
+		return ""
+	}
+	return fmt.Sprintf(", filter=%s", filter)
+}
+
+/*
+  Generate the burn rate program for ratio, which is a SignalFlow stream of the ratio of failed requests
+*/
+func getSloProgramText(ratio string, target float64, window int) string {
+	budget := 1 - target/100
+	lines := []string{fmt.Sprintf("ratio = %s", ratio)}
+	for i, alert := range sloBurnRateAlerts {
+		// The burn rate is how much faster than allowed by the SLO the error budget is consumed
+		burnRate := alert.budgetSpent * float64(window*24*60) / float64(alert.longWindow)
+		threshold := strconv.FormatFloat(burnRate*budget, 'g', 6, 64)
+		lines = append(lines,
+			fmt.Sprintf("long_%d = ratio.mean(over='%s')", i, formatSloWindow(alert.longWindow)),
+			fmt.Sprintf("short_%d = ratio.mean(over='%s')", i, formatSloWindow(alert.shortWindow)),
+			fmt.Sprintf("detect(when(long_%d > %s) and when(short_%d > %s)).publish('%s')", i, threshold, i, threshold, alert.label),
+		)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatSloWindow(minutes int) string {
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+func slodetectorCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	payload, err := getPayloadSloDetector(d, config)
+	if err != nil {
+		return fmt.Errorf("Failed creating json payload: %s", err.Error())
+	}
+
+	err = resourceCreate(DETECTOR_API_URL, config.AuthToken, payload, d)
+	if err != nil {
+		return err
+	}
+	// program_text is generated from the other fields, read it back
+	return slodetectorRead(d, meta)
+}
+
+func slodetectorRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	url := fmt.Sprintf("%s/%s", DETECTOR_API_URL, d.Id())
+
+	mapped_resp, err := resourceReadResponse(url, config.AuthToken, d)
+	if err != nil || mapped_resp == nil {
+		return err
+	}
+	if val, ok := mapped_resp["programText"].(string); ok {
+		d.Set("program_text", val)
+	}
+	readTags(d, mapped_resp, config.DefaultTags)
+	return nil
+}
+
+func slodetectorUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	payload, err := getPayloadSloDetector(d, config)
+	if err != nil {
+		return fmt.Errorf("Failed creating json payload: %s", err.Error())
+	}
+	url := fmt.Sprintf("%s/%s", DETECTOR_API_URL, d.Id())
+
+	err = resourceUpdate(url, config.AuthToken, payload, d)
+	if err != nil {
+		return err
+	}
+	return slodetectorRead(d, meta)
+}
+
+func slodetectorDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	url := fmt.Sprintf("%s/%s", DETECTOR_API_URL, d.Id())
+
+	return resourceDelete(url, config.AuthToken, d)
+}
+
+/*
+  Validates that the target is a percentage, excluding 100% (which leaves no error budget)
+*/
+func validateSloTarget(v interface{}, k string) (we []string, errors []error) {
+	value := v.(float64)
+	if value <= 0 || value >= 100 {
+		errors = append(errors, fmt.Errorf("%g not allowed; target must be > 0 && < 100", value))
+	}
+	return
+}
+
+/*
+  Validates that the SLO window (in days) is not empty
+*/
+func validateSloWindow(v interface{}, k string) (we []string, errors []error) {
+	value := v.(int)
+	if value <= 0 {
+		errors = append(errors, fmt.Errorf("%d not allowed; window must be > 0", value))
+	}
+	return
+}
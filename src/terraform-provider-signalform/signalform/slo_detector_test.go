@@ -0,0 +1,99 @@
+package signalform
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetSloProgramText(t *testing.T) {
+	// 99.9% over 30 days gives the usual 14.4 and 6 burn rates
+	expected := "ratio = data('app.errors.ratio', filter=filter('service', 'foo')).mean()\n" +
+		"long_0 = ratio.mean(over='1h')\n" +
+		"short_0 = ratio.mean(over='5m')\n" +
+		"detect(when(long_0 > 0.0144) and when(short_0 > 0.0144)).publish('Fast burn rate')\n" +
+		"long_1 = ratio.mean(over='6h')\n" +
+		"short_1 = ratio.mean(over='30m')\n" +
+		"detect(when(long_1 > 0.006) and when(short_1 > 0.006)).publish('Slow burn rate')"
+	ratio := getSloErrorRateRatio("app.errors.ratio", "filter('service', 'foo')")
+	assert.Equal(t, expected, getSloProgramText(ratio, 99.9, 30))
+}
+
+func TestGetSloLatencyRatio(t *testing.T) {
+	expected := "(data('app.latency').above(0.5, inclusive=False).count() / data('app.latency').count()).fill(0)"
+	assert.Equal(t, expected, getSloLatencyRatio("app.latency", "", 0.5))
+}
+
+func TestValidateSloTargetAllowed(t *testing.T) {
+	_, errors := validateSloTarget(99.9, "target")
+	assert.Equal(t, 0, len(errors))
+}
+
+func TestValidateSloTargetNotAllowed(t *testing.T) {
+	for _, value := range []float64{0, 100, 150} {
+		_, errors := validateSloTarget(value, "target")
+		assert.Equal(t, 1, len(errors))
+	}
+}
+
+func TestValidateSloWindow(t *testing.T) {
+	_, errors := validateSloWindow(30, "window")
+	assert.Equal(t, 0, len(errors))
+	for _, value := range []int{0, -7} {
+		_, errors := validateSloWindow(value, "window")
+		assert.Equal(t, 1, len(errors))
+	}
+}
+
+func TestGetPayloadSloDetector(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, sloDetectorResource().Schema, map[string]interface{}{
+		"name":                   "foo latency",
+		"latency_series_metric":  "app.latency",
+		"latency_threshold":      0.5,
+		"target":                 99.0,
+		"critical_notifications": []interface{}{"PagerDuty,credId"},
+	})
+	payload, err := getPayloadSloDetector(d, &signalformConfig{})
+	assert.Nil(t, err)
+
+	mapped_payload := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(payload, &mapped_payload))
+	ratio := getSloLatencyRatio("app.latency", "", 0.5)
+	assert.Equal(t, getSloProgramText(ratio, 99, 30), mapped_payload["programText"])
+	rules := mapped_payload["rules"].([]interface{})
+	assert.Equal(t, 2, len(rules))
+	assert.Equal(t, "Critical", rules[0].(map[string]interface{})["severity"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "PagerDuty", "credentialId": "credId"},
+	}, rules[0].(map[string]interface{})["notifications"])
+	// The program text is only read back from SignalFx
+	assert.Equal(t, "", d.Get("program_text"))
+}
+
+func TestGetPayloadSloDetectorMissingLatencyThreshold(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, sloDetectorResource().Schema, map[string]interface{}{
+		"name":                  "foo latency",
+		"latency_series_metric": "app.latency",
+		"target":                99.0,
+	})
+	_, err := getPayloadSloDetector(d, &signalformConfig{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "latency_threshold")
+}
+
+func TestGetPayloadSloDetectorZeroLatencyThreshold(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, sloDetectorResource().Schema, map[string]interface{}{
+		"name":                  "foo latency",
+		"latency_series_metric": "app.latency",
+		"latency_threshold":     0.0,
+		"target":                99.0,
+	})
+	payload, err := getPayloadSloDetector(d, &signalformConfig{})
+	assert.Nil(t, err)
+
+	mapped_payload := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(payload, &mapped_payload))
+	ratio := getSloLatencyRatio("app.latency", "", 0)
+	assert.Equal(t, getSloProgramText(ratio, 99, 30), mapped_payload["programText"])
+}
@@ -0,0 +1,263 @@
+package signalform
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func tableChartResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"synced": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the resource in SignalForm and SignalFx are identical or not. Used internally for syncing.",
+			},
+			"last_updated": &schema.Schema{
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Latest timestamp the resource was updated",
+			},
+			"resource_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     CHART_URL,
+				Description: "API URL of the chart",
+			},
+			"url": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the chart",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the chart",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the chart (Optional)",
+			},
+			"program_text": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Signalflow program text for the chart. More info at \"https://developers.signalfx.com/docs/signalflow-overview\"",
+			},
+			"unit_prefix": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "(Metric by default) Must be \"Metric\" or \"Binary\"",
+			},
+			"max_delay": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "How long (in seconds) to wait for late datapoints",
+				ValidateFunc: validateMaxDelayValue,
+			},
+			"disable_sampling": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "(false by default) If false, samples a subset of the output MTS, which improves UI performance",
+			},
+			"group_by": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Dimensions to group the rows of the table by",
+			},
+			"hide_timestamp": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "(false by default) Whether to hide the timestamp column of the table",
+			},
+			"sort_by": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateSortBy,
+				Description:  "The property to use when sorting the elements. Use 'value' if you want to sort by value. Must be prepended with + for ascending or - for descending (e.g. -foo)",
+			},
+			"refresh_interval": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "How often (in seconds) to refresh the values of the table",
+			},
+			"legend_fields_to_hide": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "List of properties that shouldn't be displayed as columns of the table (i.e. dimension names)",
+			},
+			"max_precision": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum number of digits to display when rounding values up or down",
+			},
+			"tags": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags associated with the chart",
+			},
+			"viz_options": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Plot-level customization options, associated with a publish statement",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"label": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The label used in the publish statement that displays the plot (metric time series data) you want to customize",
+						},
+						"display_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name to display for this plot instead of its label",
+						},
+						"hidden": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "(false by default) Whether this plot should be published but not drawn (e.g. for helper streams)",
+						},
+						"color": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Color to use",
+							ValidateFunc: validateChartColor,
+						},
+						"value_unit": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateUnitTimeChart,
+							Description:  "A unit to attach to this plot. Units support automatic scaling (eg thousands of bytes will be displayed as kilobytes, 90 seconds as 1.5m)",
+						},
+						"value_unit_prefix": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateValueUnitPrefix,
+							Description:  "(Metric by default) Prefix of the scaled values of value_unit. Must be \"Metric\" or \"Binary\"",
+						},
+						"value_prefix": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "An arbitrary prefix to display with the value of this plot",
+						},
+						"value_suffix": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "An arbitrary suffix to display with the value of this plot",
+						},
+					},
+				},
+			},
+		},
+
+		Create: tablechartCreate,
+		Read:   tablechartRead,
+		Update: tablechartUpdate,
+		Delete: tablechartDelete,
+	}
+}
+
+/*
+  Use Resource object to construct json payload in order to create a table chart
+*/
+func getPayloadTableChart(d *schema.ResourceData, config *signalformConfig) ([]byte, error) {
+	payload := map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+		"programText": sanitizeProgramText(d.Get("program_text").(string)),
+	}
+
+	viz := getTableChartOptions(d)
+	if legendOptions := getLegendOptions(d); len(legendOptions) > 0 {
+		viz["legendOptions"] = legendOptions
+	}
+	if vizOptions := getPerSignalVizOptions(d); len(vizOptions) > 0 {
+		viz["publishLabelOptions"] = vizOptions
+	}
+	if len(viz) > 0 {
+		payload["options"] = viz
+	}
+
+	if tags := getTags(d, config.DefaultTags); len(tags) > 0 {
+		payload["tags"] = tags
+	}
+
+	return json.Marshal(payload)
+}
+
+func getTableChartOptions(d *schema.ResourceData) map[string]interface{} {
+	viz := make(map[string]interface{})
+	viz["type"] = "TableChart"
+	if val, ok := d.GetOk("unit_prefix"); ok {
+		viz["unitPrefix"] = val.(string)
+	}
+
+	programOptions := make(map[string]interface{})
+	if val, ok := d.GetOk("max_delay"); ok {
+		programOptions["maxDelay"] = val.(int) * 1000
+	}
+	programOptions["disableSampling"] = d.Get("disable_sampling").(bool)
+	viz["programOptions"] = programOptions
+
+	if val, ok := d.GetOk("group_by"); ok {
+		groupBy := []string{}
+		for _, dimension := range val.([]interface{}) {
+			groupBy = append(groupBy, dimension.(string))
+		}
+		viz["groupBy"] = groupBy
+	}
+	viz["hideTimestamp"] = d.Get("hide_timestamp").(bool)
+	if sortBy, ok := d.GetOk("sort_by"); ok {
+		viz["sortBy"] = sortBy.(string)
+	}
+	if refreshInterval, ok := d.GetOk("refresh_interval"); ok {
+		viz["refreshInterval"] = refreshInterval.(int) * 1000
+	}
+	if maxPrecision, ok := d.GetOk("max_precision"); ok {
+		viz["maximumPrecision"] = maxPrecision.(int)
+	}
+
+	return viz
+}
+
+func tablechartCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	payload, err := getPayloadTableChart(d, config)
+	if err != nil {
+		return fmt.Errorf("Failed creating json payload: %s", err.Error())
+	}
+
+	return resourceCreate(CHART_API_URL, config.AuthToken, payload, d)
+}
+
+func tablechartRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	url := fmt.Sprintf("%s/%s", CHART_API_URL, d.Id())
+
+	return resourceReadTags(url, config, d)
+}
+
+func tablechartUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	payload, err := getPayloadTableChart(d, config)
+	if err != nil {
+		return fmt.Errorf("Failed creating json payload: %s", err.Error())
+	}
+	url := fmt.Sprintf("%s/%s", CHART_API_URL, d.Id())
+
+	return resourceUpdate(url, config.AuthToken, payload, d)
+}
+
+func tablechartDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*signalformConfig)
+	url := fmt.Sprintf("%s/%s", CHART_API_URL, d.Id())
+
+	return resourceDelete(url, config.AuthToken, d)
+}
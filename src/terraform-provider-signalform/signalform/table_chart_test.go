@@ -0,0 +1,45 @@
+package signalform
+
+import (
+	"encoding/json"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetPayloadTableChart(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, tableChartResource().Schema, map[string]interface{}{
+		"name":           "CPU by host",
+		"program_text":   "data('cpu.utilization').publish(label='CPU')",
+		"max_delay":      30,
+		"group_by":       []interface{}{"host"},
+		"hide_timestamp": true,
+		"sort_by":        "-value",
+		"max_precision":  2,
+		"viz_options": []interface{}{
+			map[string]interface{}{"label": "CPU", "value_suffix": "%"},
+		},
+	})
+	payload, err := getPayloadTableChart(d, &signalformConfig{})
+	assert.Nil(t, err)
+
+	mapped_payload := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(payload, &mapped_payload))
+	expected := map[string]interface{}{
+		"type": "TableChart",
+		"programOptions": map[string]interface{}{
+			"maxDelay":        30000.0,
+			"disableSampling": false,
+		},
+		"groupBy":          []interface{}{"host"},
+		"hideTimestamp":    true,
+		"sortBy":           "-value",
+		"maximumPrecision": 2.0,
+		"publishLabelOptions": []interface{}{
+			map[string]interface{}{"label": "CPU", "valueSuffix": "%"},
+		},
+	}
+	assert.Equal(t, "CPU by host", mapped_payload["name"])
+	assert.Equal(t, expected, mapped_payload["options"])
+	assert.NotContains(t, mapped_payload, "tags")
+}
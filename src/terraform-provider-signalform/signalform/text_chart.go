@@ -46,6 +46,12 @@ func textChartResource() *schema.Resource {
 				Required:    true,
 				Description: "Markdown text to display. More info at: https://github.com/adam-p/markdown-here/wiki/Markdown-Cheatsheet",
 			},
+			"tags": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags associated with the chart",
+			},
 		},
 
 		Create: textchartCreate,
@@ -58,7 +64,7 @@ func textChartResource() *schema.Resource {
 /*
   Use Resource object to construct json payload in order to create a text chart
 */
-func getPayloadTextChart(d *schema.ResourceData) ([]byte, error) {
+func getPayloadTextChart(d *schema.ResourceData, config *signalformConfig) ([]byte, error) {
 	payload := map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
@@ -69,6 +75,10 @@ func getPayloadTextChart(d *schema.ResourceData) ([]byte, error) {
 		payload["options"] = viz
 	}
 
+	if tags := getTags(d, config.DefaultTags); len(tags) > 0 {
+		payload["tags"] = tags
+	}
+
 	return json.Marshal(payload)
 }
 
@@ -84,7 +94,7 @@ func getTextChartOptions(d *schema.ResourceData) map[string]interface{} {
 
 func textchartCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadTextChart(d)
+	payload, err := getPayloadTextChart(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
@@ -96,12 +106,12 @@ func textchartRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
 	url := fmt.Sprintf("%s/%s", CHART_API_URL, d.Id())
 
-	return resourceRead(url, config.AuthToken, d)
+	return resourceReadTags(url, config, d)
 }
 
 func textchartUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadTextChart(d)
+	payload, err := getPayloadTextChart(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
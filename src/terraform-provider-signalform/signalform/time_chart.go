@@ -8,25 +8,6 @@ import (
 	"strings"
 )
 
-var PaletteColors = map[string]int{
-	"gray":       0,
-	"blue":       1,
-	"azure":      2,
-	"navy":       3,
-	"brown":      4,
-	"orange":     5,
-	"yellow":     6,
-	"magenta":    7,
-	"purple":     8,
-	"pink":       9,
-	"violet":     10,
-	"lilac":      11,
-	"iris":       12,
-	"emerald":    13,
-	"green":      14,
-	"aquamarine": 15,
-}
-
 func timeChartResource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -97,20 +78,29 @@ func timeChartResource() *schema.Resource {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ValidateFunc:  validateSignalfxRelativeTime,
-				Description:   "From when to display data. SignalFx time syntax (e.g. -5m, -1h)",
+				Description:   "From when to display data. SignalFx time syntax (e.g. -5m, -1h, -1h30m)",
+				ConflictsWith: []string{"start_time", "end_time"},
+			},
+			"time_range_end": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateSignalfxRelativeTime,
+				Description:   "(Now by default) Until when to display data. Requires time_range and must be after it. SignalFx time syntax (e.g. -1h)",
 				ConflictsWith: []string{"start_time", "end_time"},
 			},
 			"start_time": &schema.Schema{
-				Type:          schema.TypeInt,
+				Type:          schema.TypeString,
 				Optional:      true,
-				Description:   "Seconds since epoch to start the visualization",
-				ConflictsWith: []string{"time_range"},
+				ValidateFunc:  validateAbsoluteTime,
+				Description:   "Seconds since epoch or RFC3339 timestamp (e.g. 2018-01-01T00:00:00Z) to start the visualization",
+				ConflictsWith: []string{"time_range", "time_range_end"},
 			},
 			"end_time": &schema.Schema{
-				Type:          schema.TypeInt,
+				Type:          schema.TypeString,
 				Optional:      true,
-				Description:   "Seconds since epoch to end the visualization",
-				ConflictsWith: []string{"time_range"},
+				ValidateFunc:  validateAbsoluteTime,
+				Description:   "Seconds since epoch or RFC3339 timestamp (e.g. 2018-01-01T00:00:00Z) to end the visualization",
+				ConflictsWith: []string{"time_range", "time_range_end"},
 			},
 			"axis_right": &schema.Schema{
 				Type:     schema.TypeSet,
@@ -222,16 +212,79 @@ func timeChartResource() *schema.Resource {
 				Description: "Dimension to show in the on-chart legend. On-chart legend is off unless a dimension is specified. Allowed: 'metric', 'plot_label' and any dimension.",
 			},
 			"legend_fields_to_hide": &schema.Schema{
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Description: "List of properties that shouldn't be displayed in the chart legend (i.e. dimension names)",
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"legend_options_fields"},
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Description:   "List of properties that shouldn't be displayed in the chart legend (i.e. dimension names)",
+			},
+			"legend_options_fields": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"legend_fields_to_hide"},
+				Description:   "List of properties of the chart legend, in the order they should be displayed",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"property": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the property (i.e. dimension name). Use 'metric' for the metric name and 'plot_label' for the plot label",
+						},
+						"enabled": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "(true by default) Whether the property should be displayed in the chart legend",
+						},
+					},
+				},
 			},
 			"show_event_lines": &schema.Schema{
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Description: "(false by default) Whether vertical highlight lines should be drawn in the visualizations at times when events occurred",
 			},
+			"event_options": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Event overlay customization options, associated with a publish statement of events",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"label": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The label used in the publish statement of the event signal you want to overlay",
+						},
+						"display_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name to display for the events in the chart",
+						},
+						"color": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Color to use",
+							ValidateFunc: validateChartColor,
+						},
+					},
+				},
+			},
+			"histogram_options": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Options of the chart when plot_type is \"Histogram\"",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"color_theme": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Color theme to use for the histogram",
+							ValidateFunc: validateChartColor,
+						},
+					},
+				},
+			},
 			"show_data_markers": &schema.Schema{
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -267,11 +320,22 @@ func timeChartResource() *schema.Resource {
 							Required:    true,
 							Description: "The label used in the publish statement that displays the plot (metric time series data) you want to customize",
 						},
+						"display_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name to display for this plot instead of its label",
+						},
+						"hidden": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "(false by default) Whether this plot should be published but not drawn (e.g. for helper streams)",
+						},
 						"color": &schema.Schema{
 							Type:         schema.TypeString,
 							Optional:     true,
 							Description:  "Color to use",
-							ValidateFunc: validatePerSignalColor,
+							ValidateFunc: validateChartColor,
 						},
 						"axis": &schema.Schema{
 							Type:         schema.TypeString,
@@ -289,7 +353,13 @@ func timeChartResource() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							ValidateFunc: validateUnitTimeChart,
-							Description:  "A unit to attach to this plot. Units support automatic scaling (eg thousands of bytes will be displayed as kilobytes)",
+							Description:  "A unit to attach to this plot. Units support automatic scaling (eg thousands of bytes will be displayed as kilobytes, 90 seconds as 1.5m)",
+						},
+						"value_unit_prefix": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateValueUnitPrefix,
+							Description:  "(Metric by default) Prefix of the scaled values of value_unit. Must be \"Metric\" or \"Binary\"",
 						},
 						"value_prefix": &schema.Schema{
 							Type:        schema.TypeString,
@@ -316,7 +386,11 @@ func timeChartResource() *schema.Resource {
 /*
   Use Resource object to construct json payload in order to create a time chart
 */
-func getPayloadTimeChart(d *schema.ResourceData) ([]byte, error) {
+func getPayloadTimeChart(d *schema.ResourceData, config *signalformConfig) ([]byte, error) {
+	if err := validateTimeRange(d); err != nil {
+		return nil, err
+	}
+
 	payload := map[string]interface{}{
 		"name":        d.Get("name").(string),
 		"description": d.Get("description").(string),
@@ -333,6 +407,12 @@ func getPayloadTimeChart(d *schema.ResourceData) ([]byte, error) {
 	if vizOptions := getPerSignalVizOptions(d); len(vizOptions) > 0 {
 		viz["publishLabelOptions"] = vizOptions
 	}
+	if eventOptions := getEventOptions(d); len(eventOptions) > 0 {
+		viz["eventPublishLabelOptions"] = eventOptions
+	}
+	if histogramOptions := getHistogramOptions(d); len(histogramOptions) > 0 {
+		viz["histogramChartOptions"] = histogramOptions
+	}
 	if onChartLegendDim, ok := d.GetOk("on_chart_legend_dimension"); ok {
 		if onChartLegendDim == "metric" {
 			onChartLegendDim = "sf_originatingMetric"
@@ -347,11 +427,7 @@ func getPayloadTimeChart(d *schema.ResourceData) ([]byte, error) {
 	if len(viz) > 0 {
 		payload["options"] = viz
 	}
-	if val, ok := d.GetOk("tags"); ok {
-		tags := []string{}
-		for _, tag := range val.([]interface{}) {
-			tags = append(tags, tag.(string))
-		}
+	if tags := getTags(d, config.DefaultTags); len(tags) > 0 {
 		payload["tags"] = tags
 	}
 
@@ -366,10 +442,14 @@ func getPerSignalVizOptions(d *schema.ResourceData) []map[string]interface{} {
 		item := make(map[string]interface{})
 
 		item["label"] = v["label"].(string)
-		if val, ok := v["color"].(string); ok {
-			if elem, ok := PaletteColors[val]; ok {
-				item["paletteIndex"] = elem
-			}
+		if val, ok := v["display_name"].(string); ok && val != "" {
+			item["displayName"] = val
+		}
+		if val, ok := v["color"].(string); ok && val != "" {
+			item["paletteIndex"] = getPlotPaletteIndex(val)
+		}
+		if val, ok := v["hidden"].(bool); ok && val {
+			item["hidden"] = true
 		}
 		if val, ok := v["plot_type"].(string); ok && val != "" {
 			item["plotType"] = val
@@ -384,6 +464,9 @@ func getPerSignalVizOptions(d *schema.ResourceData) []map[string]interface{} {
 		if val, ok := v["value_unit"].(string); ok && val != "" {
 			item["valueUnit"] = val
 		}
+		if val, ok := v["value_unit_prefix"].(string); ok && val != "" {
+			item["valueUnitPrefix"] = val
+		}
 		if val, ok := v["value_suffix"].(string); ok && val != "" {
 			item["valueSuffix"] = val
 		}
@@ -396,6 +479,37 @@ func getPerSignalVizOptions(d *schema.ResourceData) []map[string]interface{} {
 	return viz_list
 }
 
+func getEventOptions(d *schema.ResourceData) []map[string]interface{} {
+	events := d.Get("event_options").(*schema.Set).List()
+	events_list := make([]map[string]interface{}, len(events))
+	for i, e := range events {
+		e := e.(map[string]interface{})
+		item := make(map[string]interface{})
+
+		item["label"] = e["label"].(string)
+		if val, ok := e["display_name"].(string); ok && val != "" {
+			item["displayName"] = val
+		}
+		if val, ok := e["color"].(string); ok && val != "" {
+			item["paletteIndex"] = getPlotPaletteIndex(val)
+		}
+
+		events_list[i] = item
+	}
+	return events_list
+}
+
+func getHistogramOptions(d *schema.ResourceData) map[string]interface{} {
+	item := make(map[string]interface{})
+	if tf_histogram_opts, ok := d.GetOk("histogram_options"); ok {
+		tf_opt := tf_histogram_opts.(*schema.Set).List()[0].(map[string]interface{})
+		if val, ok := tf_opt["color_theme"].(string); ok && val != "" {
+			item["colorThemeIndex"] = getPlotPaletteIndex(val)
+		}
+	}
+	return item
+}
+
 func getAxesOptions(d *schema.ResourceData) []map[string]interface{} {
 	axes_list_opts := make([]map[string]interface{}, 2)
 	if tf_axis_opts, ok := d.GetOk("axis_right"); ok {
@@ -491,21 +605,7 @@ func getTimeChartOptions(d *schema.ResourceData) map[string]interface{} {
 		viz["programOptions"] = programOptions
 	}
 
-	timeMap := make(map[string]interface{})
-	if val, ok := d.GetOk("time_range"); ok {
-		if ms, err := fromRangeToMilliSeconds(val.(string)); err == nil {
-			timeMap["range"] = ms
-			timeMap["type"] = "relative"
-		}
-	}
-	if val, ok := d.GetOk("start_time"); ok {
-		timeMap["start"] = val.(int) * 1000
-		timeMap["type"] = "absolute"
-		if val, ok := d.GetOk("end_time"); ok {
-			timeMap["end"] = val.(int) * 1000
-		}
-	}
-	if len(timeMap) > 0 {
+	if timeMap := getTimeOptions(d); len(timeMap) > 0 {
 		viz["time"] = timeMap
 	}
 
@@ -527,7 +627,7 @@ func getTimeChartOptions(d *schema.ResourceData) map[string]interface{} {
 
 func timechartCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadTimeChart(d)
+	payload, err := getPayloadTimeChart(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
@@ -539,12 +639,12 @@ func timechartRead(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
 	url := fmt.Sprintf("%s/%s", CHART_API_URL, d.Id())
 
-	return resourceRead(url, config.AuthToken, d)
+	return resourceReadTags(url, config, d)
 }
 
 func timechartUpdate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*signalformConfig)
-	payload, err := getPayloadTimeChart(d)
+	payload, err := getPayloadTimeChart(d, config)
 	if err != nil {
 		return fmt.Errorf("Failed creating json payload: %s", err.Error())
 	}
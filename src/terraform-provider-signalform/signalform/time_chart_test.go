@@ -1,6 +1,7 @@
 package signalform
 
 import (
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -16,3 +17,58 @@ func TestValidatePlotTypeTimeChartNotAllowed(t *testing.T) {
 	_, errors := validatePlotTypeTimeChart("absolute", "plot_type")
 	assert.Equal(t, len(errors), 1)
 }
+
+func TestGetPerSignalVizOptions(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, timeChartResource().Schema, map[string]interface{}{
+		"viz_options": []interface{}{
+			map[string]interface{}{
+				"label":             "A",
+				"display_name":      "Memory used",
+				"value_unit":        "Byte",
+				"value_unit_prefix": "Binary",
+			},
+			map[string]interface{}{"label": "B", "hidden": true},
+		},
+	})
+
+	viz := map[string]map[string]interface{}{}
+	for _, item := range getPerSignalVizOptions(d) {
+		viz[item["label"].(string)] = item
+	}
+	assert.Equal(t, map[string]interface{}{
+		"label":           "A",
+		"displayName":     "Memory used",
+		"valueUnit":       "Byte",
+		"valueUnitPrefix": "Binary",
+	}, viz["A"])
+	assert.Equal(t, map[string]interface{}{"label": "B", "hidden": true}, viz["B"])
+}
+
+func TestGetEventOptions(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, timeChartResource().Schema, map[string]interface{}{
+		"event_options": []interface{}{
+			map[string]interface{}{"label": "Deploys", "display_name": "Deploys of the app", "color": "orange"},
+		},
+	})
+
+	expected := []map[string]interface{}{
+		map[string]interface{}{
+			"label":        "Deploys",
+			"displayName":  "Deploys of the app",
+			"paletteIndex": getPlotPaletteIndex("orange"),
+		},
+	}
+	assert.Equal(t, expected, getEventOptions(d))
+}
+
+func TestGetHistogramOptions(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, timeChartResource().Schema, map[string]interface{}{
+		"histogram_options": []interface{}{
+			map[string]interface{}{"color_theme": "green"},
+		},
+	})
+	assert.Equal(t, map[string]interface{}{"colorThemeIndex": getPlotPaletteIndex("green")}, getHistogramOptions(d))
+
+	d = schema.TestResourceDataRaw(t, timeChartResource().Schema, map[string]interface{}{})
+	assert.Equal(t, map[string]interface{}{}, getHistogramOptions(d))
+}
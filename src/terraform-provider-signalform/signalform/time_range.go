@@ -0,0 +1,181 @@
+package signalform
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Every component must be non-zero, since SignalFx has no -0 time
+var relativeTimeRegexp = regexp.MustCompile(`^-([0-9]*[1-9][0-9]*[smhdw])+$`)
+var singleUnitRelativeTimeRegexp = regexp.MustCompile(`^-[0-9]+[smhdw]$`)
+var relativeTimeUnitRegexp = regexp.MustCompile(`([0-9]+)([smhdw])`)
+
+/*
+  Units of the SignalFx time syntax, from the largest to the smallest
+*/
+var relativeTimeUnits = []struct {
+	unit string
+	ms   int
+}{
+	{"w", 7 * 24 * 60 * 60 * 1000},
+	{"d", 24 * 60 * 60 * 1000},
+	{"h", 60 * 60 * 1000},
+	{"m", 60 * 1000},
+	{"s", 1000},
+}
+
+/*
+	Util method to validate SignalFx specific string format.
+*/
+func validateSignalfxRelativeTime(v interface{}, k string) (we []string, errors []error) {
+	ts := v.(string)
+
+	if !relativeTimeRegexp.MatchString(ts) {
+		errors = append(errors, fmt.Errorf("%s not allowed. Please use SignalFx time syntax (e.g. -30s, -5m, -1h, -1h30m)", ts))
+	}
+	return
+}
+
+/*
+	Util method to validate a timestamp, either seconds since epoch or RFC3339 (e.g. 2018-01-01T00:00:00Z).
+*/
+func validateAbsoluteTime(v interface{}, k string) (we []string, errors []error) {
+	if _, err := parseAbsoluteTime(v.(string)); err != nil {
+		errors = append(errors, err)
+	}
+	return
+}
+
+/*
+  Convert a relative time in SignalFx time syntax (e.g. -1h30m) to a duration in milliseconds
+*/
+func fromRangeToMilliSeconds(timeRange string) (int, error) {
+	if !relativeTimeRegexp.MatchString(timeRange) {
+		return -1, fmt.Errorf("%s is not a valid SignalFx time (e.g. -30s, -5m, -1h, -1h30m)", timeRange)
+	}
+	total := 0
+	for _, ss := range relativeTimeUnitRegexp.FindAllStringSubmatch(timeRange, -1) {
+		val, err := strconv.Atoi(ss[1])
+		if err != nil {
+			return -1, err
+		}
+		for _, item := range relativeTimeUnits {
+			if item.unit == ss[2] {
+				total += val * item.ms
+			}
+		}
+	}
+	return total, nil
+}
+
+/*
+  Convert a duration in milliseconds to SignalFx time syntax, using the largest unit it is a multiple of
+*/
+func fromMilliSecondsToRange(ms int) string {
+	for _, item := range relativeTimeUnits {
+		if ms%item.ms == 0 {
+			return fmt.Sprintf("-%d%s", ms/item.ms, item.unit)
+		}
+	}
+	return fmt.Sprintf("-%ds", ms/1000)
+}
+
+/*
+  Rewrite compound relative times (e.g. -1h30m) to a single unit (e.g. -90m). Other times are left untouched.
+*/
+func normalizeRelativeTime(timeRange string) string {
+	if singleUnitRelativeTimeRegexp.MatchString(timeRange) {
+		return timeRange
+	}
+	if ms, err := fromRangeToMilliSeconds(timeRange); err == nil {
+		return fromMilliSecondsToRange(ms)
+	}
+	return timeRange
+}
+
+/*
+  Convert a timestamp, either seconds since epoch or RFC3339, to milliseconds since epoch
+*/
+func parseAbsoluteTime(value string) (int64, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return seconds * 1000, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return -1, fmt.Errorf("%s not allowed. Please use seconds since epoch or RFC3339 (e.g. 2018-01-01T00:00:00Z)", value)
+	}
+	return t.UnixNano() / int64(time.Millisecond), nil
+}
+
+/*
+  Validates the time fields together: time_range_end requires time_range, and the end of the time range must be after its start
+*/
+func validateTimeRange(d *schema.ResourceData) error {
+	if end, ok := d.GetOk("time_range_end"); ok {
+		start, ok := d.GetOk("time_range")
+		if !ok {
+			return fmt.Errorf("time_range_end requires time_range")
+		}
+		startMs, err := fromRangeToMilliSeconds(start.(string))
+		if err != nil {
+			return err
+		}
+		endMs, err := fromRangeToMilliSeconds(end.(string))
+		if err != nil {
+			return err
+		}
+		if endMs >= startMs {
+			return fmt.Errorf("time_range_end (%s) must be after time_range (%s)", end, start)
+		}
+	}
+	if end, ok := d.GetOk("end_time"); ok {
+		if start, ok := d.GetOk("start_time"); ok {
+			startMs, err := parseAbsoluteTime(start.(string))
+			if err != nil {
+				return err
+			}
+			endMs, err := parseAbsoluteTime(end.(string))
+			if err != nil {
+				return err
+			}
+			if endMs <= startMs {
+				return fmt.Errorf("end_time (%s) must be after start_time (%s)", end, start)
+			}
+		}
+	}
+	return nil
+}
+
+/*
+  Get the time options of a chart from time_range/time_range_end or start_time/end_time
+*/
+func getTimeOptions(d *schema.ResourceData) map[string]interface{} {
+	timeMap := make(map[string]interface{})
+	if val, ok := d.GetOk("time_range"); ok {
+		if ms, err := fromRangeToMilliSeconds(val.(string)); err == nil {
+			timeMap["range"] = ms
+			timeMap["type"] = "relative"
+			if val, ok := d.GetOk("time_range_end"); ok {
+				if ms, err := fromRangeToMilliSeconds(val.(string)); err == nil {
+					timeMap["rangeEnd"] = ms
+				}
+			}
+		}
+	}
+	if val, ok := d.GetOk("start_time"); ok {
+		if ms, err := parseAbsoluteTime(val.(string)); err == nil {
+			timeMap["start"] = ms
+			timeMap["type"] = "absolute"
+			if val, ok := d.GetOk("end_time"); ok {
+				if ms, err := parseAbsoluteTime(val.(string)); err == nil {
+					timeMap["end"] = ms
+				}
+			}
+		}
+	}
+	return timeMap
+}
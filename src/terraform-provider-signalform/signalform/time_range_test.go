@@ -0,0 +1,107 @@
+package signalform
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidateSignalfxRelativeTimeCompound(t *testing.T) {
+	for _, value := range []string{"-30s", "-1h30m", "-1w2d"} {
+		_, errors := validateSignalfxRelativeTime(value, "time_range")
+		assert.Equal(t, 0, len(errors))
+	}
+	for _, value := range []string{"-1h30", "1h", "-5mfoo", "-", "-0s", "-0w", "-1h0m"} {
+		_, errors := validateSignalfxRelativeTime(value, "time_range")
+		assert.Equal(t, 1, len(errors))
+	}
+}
+
+func TestFromRangeToMilliSecondsCompound(t *testing.T) {
+	ms, err := fromRangeToMilliSeconds("-1h30m")
+	assert.Nil(t, err)
+	assert.Equal(t, 5400000, ms)
+	ms, err = fromRangeToMilliSeconds("-45s")
+	assert.Nil(t, err)
+	assert.Equal(t, 45000, ms)
+	_, err = fromRangeToMilliSeconds("-5M")
+	assert.NotNil(t, err)
+}
+
+func TestNormalizeRelativeTime(t *testing.T) {
+	assert.Equal(t, "-15m", normalizeRelativeTime("-15m"))
+	assert.Equal(t, "-7d", normalizeRelativeTime("-7d"))
+	assert.Equal(t, "-90m", normalizeRelativeTime("-1h30m"))
+	assert.Equal(t, "-2h", normalizeRelativeTime("-1h60m"))
+	assert.Equal(t, "-90s", normalizeRelativeTime("-1m30s"))
+}
+
+func TestParseAbsoluteTime(t *testing.T) {
+	ms, err := parseAbsoluteTime("1514764800")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1514764800000), ms)
+	ms, err = parseAbsoluteTime("2018-01-01T00:00:00Z")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1514764800000), ms)
+	ms, err = parseAbsoluteTime("2018-01-01T01:00:00+01:00")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1514764800000), ms)
+	_, err = parseAbsoluteTime("2018-01-01")
+	assert.NotNil(t, err)
+}
+
+func TestValidateAbsoluteTime(t *testing.T) {
+	_, errors := validateAbsoluteTime("2018-01-01T00:00:00Z", "start_time")
+	assert.Equal(t, 0, len(errors))
+	_, errors = validateAbsoluteTime("yesterday", "start_time")
+	assert.Equal(t, 1, len(errors))
+}
+
+func TestValidateTimeRange(t *testing.T) {
+	valid := []map[string]interface{}{
+		{"time_range": "-1d", "time_range_end": "-1h"},
+		{"time_range": "-1h"},
+		{"start_time": "2018-01-01T00:00:00Z", "end_time": "2018-01-02T00:00:00Z"},
+	}
+	for _, raw := range valid {
+		d := schema.TestResourceDataRaw(t, timeChartResource().Schema, raw)
+		assert.Nil(t, validateTimeRange(d))
+	}
+
+	invalid := map[string]map[string]interface{}{
+		"time_range_end requires time_range":                   {"time_range_end": "-1h"},
+		"time_range_end (-1d) must be after time_range (-1h)":  {"time_range": "-1h", "time_range_end": "-1d"},
+		"time_range_end (-60m) must be after time_range (-1h)": {"time_range": "-1h", "time_range_end": "-60m"},
+		"end_time (1514764800) must be after start_time":       {"start_time": "2018-01-01T00:00:00Z", "end_time": "1514764800"},
+	}
+	for message, raw := range invalid {
+		d := schema.TestResourceDataRaw(t, timeChartResource().Schema, raw)
+		err := validateTimeRange(d)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), message)
+	}
+}
+
+func TestGetTimeOptions(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, timeChartResource().Schema, map[string]interface{}{
+		"time_range":     "-1d",
+		"time_range_end": "-1h",
+	})
+	expected := map[string]interface{}{
+		"range":    86400000,
+		"rangeEnd": 3600000,
+		"type":     "relative",
+	}
+	assert.Equal(t, expected, getTimeOptions(d))
+
+	d = schema.TestResourceDataRaw(t, timeChartResource().Schema, map[string]interface{}{
+		"start_time": "2018-01-01T00:00:00Z",
+		"end_time":   "1514851200",
+	})
+	expected = map[string]interface{}{
+		"start": int64(1514764800000),
+		"end":   int64(1514851200000),
+		"type":  "absolute",
+	}
+	assert.Equal(t, expected, getTimeOptions(d))
+}
@@ -8,7 +8,7 @@ import (
 	"math"
 	"net/http"
 	"regexp"
-	"strconv"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -21,43 +21,21 @@ const (
 	CHART_URL     = "https://app.signalfx.com/#/chart/<id>"
 )
 
-type chartColor struct {
-	name string
-	hex  string
-}
-
-var ChartColorsSlice = []chartColor{
-	{"gray", "#999999"},
-	{"blue", "#0077c2"},
-	{"light_blue", "#00b9ff"},
-	{"navy", "#6CA2B7"},
-	{"dark_orange", "#b04600"},
-	{"orange", "#f47e00"},
-	{"dark_yellow", "#e5b312"},
-	{"magenta", "#bd468d"},
-	{"cerise", "#e9008a"},
-	{"pink", "#ff8dd1"},
-	{"violet", "#876ff3"},
-	{"purple", "#a747ff"},
-	{"gray_blue", "#ab99bc"},
-	{"dark_green", "#007c1d"},
-	{"green", "#05ce00"},
-	{"aquamarine", "#0dba8f"},
-	{"red", "#ea1849"},
-	{"yellow", "#ea1849"},
-	{"vivid_yellow", "#ea1849"},
-	{"light_green", "#acef7f"},
-	{"lime_green", "#6bd37e"},
-}
-
 /*
   Utility function that wraps http calls to SignalFx
 */
 func sendRequest(method string, url string, token string, payload []byte) (int, []byte, error) {
+	return sendRequestWithContentType(method, url, "application/json", token, payload)
+}
+
+/*
+  Same as sendRequest, for the few SignalFx endpoints (e.g. SignalFlow) that don't take json
+*/
+func sendRequestWithContentType(method string, url string, contentType string, token string, payload []byte) (int, []byte, error) {
 	client := &http.Client{}
 
 	req, err := http.NewRequest(method, url, bytes.NewReader(payload))
-	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Content-Type", contentType)
 	req.Header.Add("X-SF-Token", token)
 
 	resp, err := client.Do(req)
@@ -86,6 +64,28 @@ func validateMaxDelayValue(v interface{}, k string) (we []string, errors []error
 	return
 }
 
+/*
+  Validates min_delay field; it must be between 0 and 900 seconds (15m in).
+*/
+func validateMinDelayValue(v interface{}, k string) (we []string, errors []error) {
+	value := v.(int)
+	if value < 0 || value > 900 {
+		errors = append(errors, fmt.Errorf("%d not allowed; min_delay must be >= 0 && <= 900", value))
+	}
+	return
+}
+
+/*
+  Validates durations (in seconds) such as auto_resolve_after or reminder_interval; they can't be negative.
+*/
+func validateNonNegativeDuration(v interface{}, k string) (we []string, errors []error) {
+	value := v.(int)
+	if value < 0 {
+		errors = append(errors, fmt.Errorf("%d not allowed; %s must be >= 0", value, k))
+	}
+	return
+}
+
 /*
   Validates that sort_by field start with either + or -.
 */
@@ -97,6 +97,36 @@ func validateSortBy(v interface{}, k string) (we []string, errors []error) {
 	return
 }
 
+/*
+  Validates the secondary_visualization field against a list of allowed words.
+*/
+func validateSecondaryVisualization(v interface{}, k string) (we []string, errors []error) {
+	value := v.(string)
+	allowedWords := []string{"None", "Radial", "Linear", "Sparkline"}
+	for _, word := range allowedWords {
+		if value == word {
+			return
+		}
+	}
+	errors = append(errors, fmt.Errorf("%s not allowed; must be one of: %s", value, strings.Join(allowedWords, ", ")))
+	return
+}
+
+/*
+  Validates the value_unit_prefix field against a list of allowed words.
+*/
+func validateValueUnitPrefix(v interface{}, k string) (we []string, errors []error) {
+	value := v.(string)
+	allowedWords := []string{"Metric", "Binary"}
+	for _, word := range allowedWords {
+		if value == word {
+			return
+		}
+	}
+	errors = append(errors, fmt.Errorf("%s not allowed; must be one of: %s", value, strings.Join(allowedWords, ", ")))
+	return
+}
+
 /*
 	Get Color Scale Options
 */
@@ -125,19 +155,115 @@ func getColorScaleOptionsFromSlice(colorScale []interface{}) []interface{} {
 		if scale["lte"].(float64) != math.MaxFloat32 {
 			options["lte"] = scale["lte"].(float64)
 		}
-		paletteIndex := 0
-		for index, thing := range ChartColorsSlice {
-			if scale["color"] == thing.name {
-				paletteIndex = index
-				break
-			}
-		}
-		options["paletteIndex"] = paletteIndex
+		options["paletteIndex"] = getScalePaletteIndex(scale["color"].(string))
 		item[i] = options
 	}
 	return item
 }
 
+type colorScaleBound struct {
+	value     float64
+	inclusive bool
+	set       bool
+}
+
+/*
+  Validates a bound (gt, gte, lt or lte) of a color_scale range. The default value, math.MaxFloat32, means that the
+  bound is not set, so it can't be used as a bound.
+*/
+func validateColorScaleBound(v interface{}, k string) (we []string, errors []error) {
+	value := v.(float64)
+	if math.IsNaN(value) || math.Abs(value) >= math.MaxFloat32 {
+		errors = append(errors, fmt.Errorf("%v not allowed; must be greater than %v and less than %v", value, -math.MaxFloat32, math.MaxFloat32))
+	}
+	return
+}
+
+/*
+  Validates that the ranges of a color_scale are well formed, and that they neither overlap nor leave gaps between them
+*/
+func validateColorScale(colorScale []interface{}) error {
+	type colorRange struct {
+		color string
+		lower colorScaleBound
+		upper colorScaleBound
+	}
+	ranges := make([]colorRange, len(colorScale))
+	for i := range colorScale {
+		scale := colorScale[i].(map[string]interface{})
+		r := colorRange{color: scale["color"].(string)}
+		gt, gte := scale["gt"].(float64), scale["gte"].(float64)
+		lt, lte := scale["lt"].(float64), scale["lte"].(float64)
+		if gt != math.MaxFloat32 && gte != math.MaxFloat32 {
+			return fmt.Errorf("color_scale %s: gt and gte can't be both set", r.color)
+		}
+		if lt != math.MaxFloat32 && lte != math.MaxFloat32 {
+			return fmt.Errorf("color_scale %s: lt and lte can't be both set", r.color)
+		}
+		if gt != math.MaxFloat32 {
+			r.lower = colorScaleBound{gt, false, true}
+		} else if gte != math.MaxFloat32 {
+			r.lower = colorScaleBound{gte, true, true}
+		}
+		if lt != math.MaxFloat32 {
+			r.upper = colorScaleBound{lt, false, true}
+		} else if lte != math.MaxFloat32 {
+			r.upper = colorScaleBound{lte, true, true}
+		}
+		if !r.lower.set && !r.upper.set {
+			return fmt.Errorf("color_scale %s: at least one of gt, gte, lt or lte must be set", r.color)
+		}
+		if r.lower.set && r.upper.set {
+			if r.lower.value > r.upper.value || (r.lower.value == r.upper.value && !(r.lower.inclusive && r.upper.inclusive)) {
+				return fmt.Errorf("color_scale %s: range %s is empty", r.color, formatColorScaleRange(r.lower, r.upper))
+			}
+		}
+		ranges[i] = r
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		if !ranges[i].lower.set || !ranges[j].lower.set {
+			return !ranges[i].lower.set && ranges[j].lower.set
+		}
+		if ranges[i].lower.value != ranges[j].lower.value {
+			return ranges[i].lower.value < ranges[j].lower.value
+		}
+		return ranges[i].lower.inclusive && !ranges[j].lower.inclusive
+	})
+	for i := 1; i < len(ranges); i++ {
+		prev, next := ranges[i-1], ranges[i]
+		prevRange := formatColorScaleRange(prev.lower, prev.upper)
+		nextRange := formatColorScaleRange(next.lower, next.upper)
+		if !prev.upper.set || !next.lower.set || prev.upper.value > next.lower.value ||
+			(prev.upper.value == next.lower.value && prev.upper.inclusive && next.lower.inclusive) {
+			return fmt.Errorf("color_scale %s %s overlaps with color_scale %s %s", prev.color, prevRange, next.color, nextRange)
+		}
+		if prev.upper.value < next.lower.value ||
+			(prev.upper.value == next.lower.value && !prev.upper.inclusive && !next.lower.inclusive) {
+			return fmt.Errorf("color_scale %s %s and color_scale %s %s leave a gap between them", prev.color, prevRange, next.color, nextRange)
+		}
+	}
+	return nil
+}
+
+func formatColorScaleRange(lower colorScaleBound, upper colorScaleBound) string {
+	text := "(-inf"
+	if lower.set {
+		if lower.inclusive {
+			text = fmt.Sprintf("[%v", lower.value)
+		} else {
+			text = fmt.Sprintf("(%v", lower.value)
+		}
+	}
+	if upper.set {
+		if upper.inclusive {
+			return fmt.Sprintf("%s, %v]", text, upper.value)
+		}
+		return fmt.Sprintf("%s, %v)", text, upper.value)
+	}
+	return text + ", +inf)"
+}
+
 /*
   Send a GET to get the current state of the resource. It just checks if the lastUpdated timestamp is
   later than the timestamp saved in the resource. If so, the resource has been modified in some way
@@ -145,12 +271,21 @@ func getColorScaleOptionsFromSlice(colorScale []interface{}) []interface{} {
   true in the tf configuration, it will update the resource to achieve the desired state.
 */
 func resourceRead(url string, sfxToken string, d *schema.ResourceData) error {
+	_, err := resourceReadResponse(url, sfxToken, d)
+	return err
+}
+
+/*
+  Same as resourceRead, but also returns the unmarshaled response so that callers can read
+  resource-specific computed fields. The returned map is nil if the resource no longer exists.
+*/
+func resourceReadResponse(url string, sfxToken string, d *schema.ResourceData) (map[string]interface{}, error) {
 	status_code, resp_body, err := sendRequest("GET", url, sfxToken, nil)
+	mapped_resp := map[string]interface{}{}
 	if status_code == 200 {
-		mapped_resp := map[string]interface{}{}
 		err = json.Unmarshal(resp_body, &mapped_resp)
 		if err != nil {
-			return fmt.Errorf("Failed unmarshaling for the resource %s during read: %s", d.Get("name"), err.Error())
+			return nil, fmt.Errorf("Failed unmarshaling for the resource %s during read: %s", d.Get("name"), err.Error())
 		}
 		// This implies the resource was modified in the Signalfx UI and therefore it is not synced with Signalform
 		last_updated := mapped_resp["lastUpdated"].(float64)
@@ -169,14 +304,46 @@ func resourceRead(url string, sfxToken string, d *schema.ResourceData) error {
 		if status_code == 404 && strings.Contains(string(resp_body), " not found") {
 			// This implies that the resouce was deleted in the Signalfx UI and therefore we need to recreate it
 			d.SetId("")
+			return nil, nil
 		} else {
-			return fmt.Errorf("For the resource %s SignalFx returned status %d: \n%s", d.Get("name"), status_code, resp_body)
+			return nil, fmt.Errorf("For the resource %s SignalFx returned status %d: \n%s", d.Get("name"), status_code, resp_body)
 		}
 	}
 
+	return mapped_resp, nil
+}
+
+/*
+  Same as resourceRead, for the resources with tags. The default tags of the provider are not saved in the resource:
+  when default_tags changes, the tags in SignalFx no longer match, which is also signaled by setting synced to false.
+*/
+func resourceReadTags(url string, config *signalformConfig, d *schema.ResourceData) error {
+	mapped_resp, err := resourceReadResponse(url, config.AuthToken, d)
+	if err != nil || mapped_resp == nil {
+		return err
+	}
+	readTags(d, mapped_resp, config.DefaultTags)
 	return nil
 }
 
+/*
+  Set synced to false if the tags in SignalFx differ (in any order) from the tags merged with defaultTags
+*/
+func readTags(d *schema.ResourceData, mapped_resp map[string]interface{}, defaultTags []string) {
+	tags := []string{}
+	if val, ok := mapped_resp["tags"].([]interface{}); ok {
+		for _, tag := range val {
+			tags = append(tags, tag.(string))
+		}
+	}
+	expected := getTags(d, defaultTags)
+	sort.Strings(tags)
+	sort.Strings(expected)
+	if strings.Join(tags, "\n") != strings.Join(expected, "\n") {
+		d.Set("synced", false)
+	}
+}
+
 /*
   Fetches payload specified in terraform configuration and creates a resource
 */
@@ -242,19 +409,29 @@ func resourceDelete(url string, sfxToken string, d *schema.ResourceData) error {
 	Util method to get Legend Chart Options.
 */
 func getLegendOptions(d *schema.ResourceData) map[string]interface{} {
+	if fields, ok := d.GetOk("legend_options_fields"); ok {
+		fields := fields.([]interface{})
+		legendOptions := make(map[string]interface{})
+		properties_opts := make([]map[string]interface{}, len(fields))
+		for i, field := range fields {
+			field := field.(map[string]interface{})
+			item := make(map[string]interface{})
+			item["property"] = getLegendPropertyName(field["property"].(string))
+			item["enabled"] = field["enabled"].(bool)
+			properties_opts[i] = item
+		}
+		if len(properties_opts) > 0 {
+			legendOptions["fields"] = properties_opts
+			return legendOptions
+		}
+	}
 	if properties, ok := d.GetOk("legend_fields_to_hide"); ok {
 		properties := properties.(*schema.Set).List()
 		legendOptions := make(map[string]interface{})
 		properties_opts := make([]map[string]interface{}, len(properties))
 		for i, property := range properties {
-			property := property.(string)
-			if property == "metric" {
-				property = "sf_originatingMetric"
-			} else if property == "plot_label" || property == "Plot Label" {
-				property = "sf_metric"
-			}
 			item := make(map[string]interface{})
-			item["property"] = property
+			item["property"] = getLegendPropertyName(property.(string))
 			item["enabled"] = false
 			properties_opts[i] = item
 		}
@@ -267,67 +444,66 @@ func getLegendOptions(d *schema.ResourceData) map[string]interface{} {
 }
 
 /*
-	Util method to validate SignalFx specific string format.
+	Util method to translate the aliases of the legend properties to their SignalFx names.
 */
-func validateSignalfxRelativeTime(v interface{}, k string) (we []string, errors []error) {
-	ts := v.(string)
-
-	r, _ := regexp.Compile("-([0-9]+)[mhdw]")
-	if !r.MatchString(ts) {
-		errors = append(errors, fmt.Errorf("%s not allowed. Please use milliseconds from epoch or SignalFx time syntax (e.g. -5m, -1h)", ts))
+func getLegendPropertyName(property string) string {
+	if property == "metric" {
+		return "sf_originatingMetric"
+	} else if property == "plot_label" || property == "Plot Label" {
+		return "sf_metric"
 	}
-	return
+	return property
 }
 
 /*
-*  Util method to convert from Signalfx string format to milliseconds
- */
-func fromRangeToMilliSeconds(timeRange string) (int, error) {
-	r := regexp.MustCompile("-([0-9]+)([mhdw])")
-	ss := r.FindStringSubmatch(timeRange)
-	var c int
-	switch ss[2] {
-	case "m":
-		c = 60 * 1000
-	case "h":
-		c = 60 * 60 * 1000
-	case "d":
-		c = 24 * 60 * 60 * 1000
-	case "w":
-		c = 7 * 24 * 60 * 60 * 1000
-	default:
-		c = 1
-	}
-	val, err := strconv.Atoi(ss[1])
-	if err != nil {
-		return -1, err
-	}
-	return val * c, nil
+  Sanitize program_text to reduce the errors we get back from SignalFx
+*/
+func sanitizeProgramText(text string) string {
+	r, _ := regexp.Compile("\n[\t\n\v\f\r ]+")
+	sane := r.ReplaceAllString(text, "\n")
+	r, _ = regexp.Compile("^[\t\n\v\f\r ]+")
+	sane = r.ReplaceAllString(sane, "")
+	return sane
 }
 
 /*
-  Validates the color field against a list of allowed words.
+  Get the tags of the resource, merged with the default tags of the provider. A resource tag wins over a default
+  tag with the same key, i.e. the same text before ":" (e.g. "owner:foo" wins over a default "owner:bar").
 */
-func validatePerSignalColor(v interface{}, k string) (we []string, errors []error) {
-	value := v.(string)
-	if _, ok := PaletteColors[value]; !ok {
-		keys := make([]string, 0, len(PaletteColors))
-		for k := range PaletteColors {
-			keys = append(keys, k)
+func getTags(d *schema.ResourceData, defaultTags []string) []string {
+	tags := []string{}
+	if val, ok := d.GetOk("tags"); ok {
+		for _, tag := range val.([]interface{}) {
+			tags = append(tags, tag.(string))
 		}
-		joinedColors := strings.Join(keys, ",")
-		errors = append(errors, fmt.Errorf("%s not allowed; must be either %s", value, joinedColors))
 	}
-	return
+	return mergeTags(tags, defaultTags)
+}
+
+func mergeTags(tags []string, defaultTags []string) []string {
+	merged := append([]string{}, tags...)
+	keys := make(map[string]bool)
+	for _, tag := range tags {
+		keys[strings.SplitN(tag, ":", 2)[0]] = true
+	}
+	for _, tag := range defaultTags {
+		key := strings.SplitN(tag, ":", 2)[0]
+		if !keys[key] {
+			keys[key] = true
+			merged = append(merged, tag)
+		}
+	}
+	return merged
 }
 
 /*
-  Sanitize program_text to reduce the errors we get back from SignalFx
+  Util method to check whether a list of strings contains value
 */
-func sanitizeProgramText(text string) string {
-	r, _ := regexp.Compile("\n[\t\n\v\f\r ]+")
-	sane := r.ReplaceAllString(text, "\n")
-	r, _ = regexp.Compile("^[\t\n\v\f\r ]+")
-	sane = r.ReplaceAllString(sane, "")
-	return sane
+func stringInSlice(value string, list []string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
 }
@@ -2,10 +2,12 @@ package signalform
 
 import (
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -88,6 +90,16 @@ func TestValidateSortByNoDirection(t *testing.T) {
 	assert.Equal(t, 1, len(errors))
 }
 
+func TestValidateNonNegativeDuration(t *testing.T) {
+	_, errors := validateNonNegativeDuration(0, "auto_resolve_after")
+	assert.Equal(t, 0, len(errors))
+	_, errors = validateNonNegativeDuration(3600, "reminder_interval")
+	assert.Equal(t, 0, len(errors))
+	_, errors = validateNonNegativeDuration(-60, "reminder_interval")
+	assert.Equal(t, 1, len(errors))
+	assert.Contains(t, errors[0].Error(), "reminder_interval must be >= 0")
+}
+
 func TestSanitizeProgramTextSane(t *testing.T) {
 	text := "previous = data('statmonster.inbound_lines',filter('source_region','${var.clusters_no_uswest2[count.index]}')).timeshift('2m').sum()\nsignal = data('statmonster.inbo    und_lines',filter('source_region','${var.clusters_no_uswest2[count.index]}')).sum()\ndetect('Low number of log lines', when(signal < (previous * 0.50), '2m', 0.90))"
 	assert.Equal(t, text, sanitizeProgramText(text))
@@ -116,3 +128,113 @@ func TestCorrectColorValue(t *testing.T) {
 	assert.Equal(t, 7, ret["paletteIndex"])
 
 }
+
+func TestMergeTags(t *testing.T) {
+	tags := []string{"owner:team-a", "critical"}
+	defaultTags := []string{"owner:team-b", "critical", "env:prod"}
+	assert.Equal(t, []string{"owner:team-a", "critical", "env:prod"}, mergeTags(tags, defaultTags))
+	assert.Equal(t, []string{"env:prod"}, mergeTags([]string{}, []string{"env:prod"}))
+	assert.Equal(t, []string{}, mergeTags([]string{}, nil))
+}
+
+func TestGetLegendPropertyName(t *testing.T) {
+	assert.Equal(t, "sf_originatingMetric", getLegendPropertyName("metric"))
+	assert.Equal(t, "sf_metric", getLegendPropertyName("plot_label"))
+	assert.Equal(t, "sf_metric", getLegendPropertyName("Plot Label"))
+	assert.Equal(t, "host", getLegendPropertyName("host"))
+}
+
+func TestValidateSecondaryVisualization(t *testing.T) {
+	for _, value := range []string{"None", "Radial", "Linear", "Sparkline"} {
+		_, errors := validateSecondaryVisualization(value, "secondary_visualization")
+		assert.Equal(t, 0, len(errors))
+	}
+	_, errors := validateSecondaryVisualization("Pie", "secondary_visualization")
+	assert.Equal(t, 1, len(errors))
+}
+
+func TestValidateValueUnitPrefix(t *testing.T) {
+	for _, value := range []string{"Metric", "Binary"} {
+		_, errors := validateValueUnitPrefix(value, "value_unit_prefix")
+		assert.Equal(t, 0, len(errors))
+	}
+	_, errors := validateValueUnitPrefix("binary", "value_unit_prefix")
+	assert.Equal(t, 1, len(errors))
+}
+
+func colorScaleRange(color string, bounds map[string]float64) map[string]interface{} {
+	scale := map[string]interface{}{
+		"color": color,
+		"gt":    math.MaxFloat32,
+		"gte":   math.MaxFloat32,
+		"lt":    math.MaxFloat32,
+		"lte":   math.MaxFloat32,
+	}
+	for k, v := range bounds {
+		scale[k] = v
+	}
+	return scale
+}
+
+func TestValidateColorScaleBound(t *testing.T) {
+	for _, value := range []float64{0, -60.5, 1e9} {
+		_, errors := validateColorScaleBound(value, "gt")
+		assert.Equal(t, 0, len(errors))
+	}
+	for _, value := range []float64{math.MaxFloat32, -math.MaxFloat32, math.Inf(1), math.NaN()} {
+		_, errors := validateColorScaleBound(value, "gt")
+		assert.Equal(t, 1, len(errors))
+	}
+}
+
+func TestValidateColorScale(t *testing.T) {
+	colorScale := []interface{}{
+		colorScaleRange("red", map[string]float64{"gt": 80}),
+		colorScaleRange("green", map[string]float64{"lt": 60}),
+		colorScaleRange("dark_yellow", map[string]float64{"gte": 60, "lte": 80}),
+	}
+	assert.Nil(t, validateColorScale(colorScale))
+}
+
+func TestValidateColorScaleOverlap(t *testing.T) {
+	colorScale := []interface{}{
+		colorScaleRange("green", map[string]float64{"lte": 60}),
+		colorScaleRange("red", map[string]float64{"gte": 60}),
+	}
+	err := validateColorScale(colorScale)
+	assert.Equal(t, "color_scale green (-inf, 60] overlaps with color_scale red [60, +inf)", err.Error())
+}
+
+func TestValidateColorScaleGap(t *testing.T) {
+	colorScale := []interface{}{
+		colorScaleRange("green", map[string]float64{"lt": 60}),
+		colorScaleRange("red", map[string]float64{"gt": 60}),
+	}
+	err := validateColorScale(colorScale)
+	assert.Equal(t, "color_scale green (-inf, 60) and color_scale red (60, +inf) leave a gap between them", err.Error())
+}
+
+func TestValidateColorScaleInconsistentBounds(t *testing.T) {
+	err := validateColorScale([]interface{}{colorScaleRange("red", map[string]float64{"gt": 60, "gte": 60})})
+	assert.Equal(t, "color_scale red: gt and gte can't be both set", err.Error())
+	err = validateColorScale([]interface{}{colorScaleRange("red", map[string]float64{"gt": 80, "lt": 60})})
+	assert.Equal(t, "color_scale red: range (80, 60) is empty", err.Error())
+	err = validateColorScale([]interface{}{colorScaleRange("red", map[string]float64{})})
+	assert.Equal(t, "color_scale red: at least one of gt, gte, lt or lte must be set", err.Error())
+}
+
+func TestReadTags(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, timeChartResource().Schema, map[string]interface{}{
+		"name": "chart",
+		"tags": []interface{}{"owner:foo"},
+	})
+	mapped_resp := map[string]interface{}{
+		"tags": []interface{}{"managed-by:terraform", "owner:foo"},
+	}
+	readTags(d, mapped_resp, []string{"owner:bar", "managed-by:terraform"})
+	assert.Equal(t, true, d.Get("synced"))
+
+	// default_tags changed since the chart was applied
+	readTags(d, mapped_resp, []string{"managed-by:signalform"})
+	assert.Equal(t, false, d.Get("synced"))
+}